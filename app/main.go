@@ -1,43 +1,111 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"marketplace/config"
 	"marketplace/pkg/DI"
+	"marketplace/pkg/lifecycle"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	// Load .env file
-	err := godotenv.Load("../.env")
-	if err != nil {
+	if err := run(); err != nil {
 		fmt.Println(err)
-		fmt.Println("Error loading .env file")
-		return
+		os.Exit(1)
+	}
+}
+
+// run собирает приложение, запускает его и при SIGINT или SIGTERM останавливает:
+// новые соединения перестают приниматься, начатые запросы завершаются в пределах
+// app.shutdown_timeout, после чего компоненты останавливаются в обратном порядке
+func run() error {
+	// Загрузка настроек из файла, окружения и флагов
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		return err
 	}
 	container := DI.Container()
+	lc := lifecycle.New()
 	e := echo.New()
 
+	if err := DI.RegisterConfig(container, cfg); err != nil {
+		return fmt.Errorf("failed to register config: %v", err)
+	}
+	if err := DI.RegisterLifecycle(container, lc); err != nil {
+		return fmt.Errorf("failed to register lifecycle: %v", err)
+	}
+
 	if err := DI.RegisterDatabases(container); err != nil {
-		fmt.Printf("Failed to register databases: %v\n", err)
-		return
+		return fmt.Errorf("failed to register databases: %v", err)
 	}
 	// Регистрация всех зависимостей
 	if err := DI.RegisterDependencies(container); err != nil {
-		fmt.Printf("Failed to register dependencies: %v\n", err)
-		return
+		return fmt.Errorf("failed to register dependencies: %v", err)
 	}
 
 	// Регистрация midleware
 	if err := DI.RegisterMiddleware(container, e); err != nil {
-		fmt.Printf("Failed to register midleware: %v\n", err)
-		return
+		return fmt.Errorf("failed to register midleware: %v", err)
 	}
 
 	if err := DI.RegisterRoutes(container, e); err != nil {
-		fmt.Printf("Failed to register routes: %v\n", err)
-		return
+		return fmt.Errorf("failed to register routes: %v", err)
+	}
+
+	// HTTP-сервер запускается последним и останавливается первым
+	serverErrors := make(chan error, 1)
+	lc.Append(lifecycle.Hook{
+		Name: "http server",
+		OnStart: func(ctx context.Context) error {
+			// Порт занимается сразу, чтобы ошибка прервала запуск
+			listener, err := net.Listen("tcp", cfg.HTTP.Addr)
+			if err != nil {
+				return err
+			}
+			e.Listener = listener
+			go func() {
+				if err := e.Start(cfg.HTTP.Addr); !errors.Is(err, http.ErrServerClosed) {
+					serverErrors <- fmt.Errorf("http server failed: %v", err)
+				}
+			}()
+			return nil
+		},
+		OnStop: e.Shutdown,
+	})
+
+	// Сигнал прерывает и запуск, и работу; повторный сигнал во время остановки завершает процесс сразу
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	// Запуск компонентов
+	startCtx, cancel := context.WithTimeout(signalCtx, cfg.App.StartTimeout)
+	defer cancel()
+	runErr := lc.Start(startCtx)
+	if runErr == nil {
+		select {
+		case <-signalCtx.Done():
+			logrus.Info("Shutting down")
+		case runErr = <-serverErrors:
+		}
+	} else if signalCtx.Err() != nil {
+		logrus.Info("Shutting down")
+		runErr = nil
+	}
+	stopSignals()
+
+	// Остановка запущенных компонентов, в том числе после неудачного запуска
+	stopCtx, cancel := context.WithTimeout(context.Background(), cfg.App.ShutdownTimeout)
+	defer cancel()
+	if err := lc.Stop(stopCtx); err != nil {
+		return errors.Join(runErr, err)
 	}
-	// Запуск сервера
-	e.Logger.Fatal(e.Start(":8080"))
+	return runErr
 }
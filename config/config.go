@@ -1 +1,456 @@
 package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config настройки приложения. Значения применяются в порядке возрастания приоритета:
+// значения по умолчанию, YAML-файл, переменные окружения (в том числе из .env) и флаги.
+type Config struct {
+	App        AppConfig            `yaml:"app"`
+	HTTP       HTTPConfig           `yaml:"http"`
+	Redis      RedisConfig          `yaml:"redis"`
+	JWT        JWTConfig            `yaml:"jwt"`
+	Storage    StorageConfig        `yaml:"storage"`
+	Mail       MailConfig           `yaml:"mail"`
+	Security   SecurityConfig       `yaml:"security"`
+	RateLimits map[string]RateLimit `yaml:"rate_limits"` // Переопределения лимитов по имени политики
+	Moderation ModerationConfig     `yaml:"moderation"`
+	Events     EventsConfig         `yaml:"events"`
+	Ledger     LedgerConfig         `yaml:"ledger"`
+	Payments   PaymentsConfig       `yaml:"payments"`
+}
+
+// AppConfig общие настройки приложения
+type AppConfig struct {
+	Env         string   `yaml:"env"`          // Окружение; в Dev логируются запросы и ответы
+	URL         string   `yaml:"url"`          // Адрес клиентского приложения для ссылок в письмах
+	AdminEmails []string `yaml:"admin_emails"` // Email администраторов маркетплейса
+
+	StartTimeout    time.Duration `yaml:"start_timeout"`    // Сколько ждать запуска компонентов
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // Сколько ждать завершения запросов и фоновых задач при остановке
+}
+
+// IsDev сообщает, запущено ли приложение в окружении разработки
+func (c AppConfig) IsDev() bool {
+	return c.Env == "Dev"
+}
+
+// HTTPConfig настройки HTTP-сервера
+type HTTPConfig struct {
+	Addr string `yaml:"addr"` // Адрес, на котором сервер принимает соединения
+	// Подсети обратных прокси (CIDR), которым можно доверять X-Forwarded-For.
+	// Пусто — IP клиента берется из соединения, заголовки игнорируются.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// TrustedProxyNets разбирает подсети доверенных прокси; отдельный адрес считается подсетью из одного адреса
+func (c HTTPConfig) TrustedProxyNets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %q", proxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// RedisConfig настройки подключения к Redis
+type RedisConfig struct {
+	Addr     string `yaml:"addr"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+}
+
+// JWTConfig настройки подписи токенов
+type JWTConfig struct {
+	Secret string `yaml:"secret"` // Ключ подписи токенов доступа и одноразовых токенов
+}
+
+// StorageConfig настройки хранилища файлов
+type StorageConfig struct {
+	Backend         string   `yaml:"backend"`           // local или s3
+	LocalDir        string   `yaml:"local_dir"`         // Каталог локального хранилища
+	PrivateLocalDir string   `yaml:"private_local_dir"` // Каталог закрытых файлов, не раздается по /media
+	S3              S3Config `yaml:"s3"`
+}
+
+// S3Config настройки S3-совместимого хранилища
+type S3Config struct {
+	Endpoint      string `yaml:"endpoint"`
+	AccessKey     string `yaml:"access_key"`
+	SecretKey     string `yaml:"secret_key"`
+	Bucket        string `yaml:"bucket"`
+	PrivateBucket string `yaml:"private_bucket"` // Бакет закрытых файлов без публичного доступа; пусто — "<bucket>-private"
+	UseSSL        bool   `yaml:"use_ssl"`
+	PublicURL     string `yaml:"public_url"` // Адрес, по которому файлы доступны клиентам
+}
+
+// MailConfig настройки отправки писем
+type MailConfig struct {
+	Mailer  string     `yaml:"mailer"`   // file (в каталог DumpDir) или smtp
+	DumpDir string     `yaml:"dump_dir"` // Каталог для писем при Mailer=file
+	From    string     `yaml:"from"`
+	SMTP    SMTPConfig `yaml:"smtp"`
+}
+
+// SMTPConfig настройки SMTP-сервера
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// SecurityConfig требования к учетным записям
+type SecurityConfig struct {
+	RequireVerifiedSellers bool     `yaml:"require_verified_sellers"` // Действия продавца только с подтвержденным email
+	Require2FARoles        []string `yaml:"require_2fa_roles"`        // Роли (admin, seller), которым обязательна 2FA
+}
+
+// ModerationConfig настройки модерации вопросов и ответов
+type ModerationConfig struct {
+	StopWords []string `yaml:"stop_words"` // Слова, при которых текст уходит на ручную модерацию
+}
+
+// EventsConfig настройки событий реального времени
+type EventsConfig struct {
+	WSAllowedOrigins []string `yaml:"ws_allowed_origins"` // Разрешенные origin для WebSocket; пусто — только тот же origin
+}
+
+// LedgerConfig настройки курсов валют и расчетов с продавцами
+type LedgerConfig struct {
+	ExchangeRatesFile string        `yaml:"exchange_rates_file"` // Файл с курсами валют, загружаемый при старте
+	PayoutInterval    time.Duration `yaml:"payout_interval"`     // Интервал формирования выплат; 0 — только вручную
+}
+
+// RateLimit лимит запросов в формате "<запросов>/<окно>", например "100/1m"
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// UnmarshalText разбирает лимит из строки "<запросов>/<окно>". Строка "0" отключает ограничение.
+func (r *RateLimit) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "0" {
+		r.Limit, r.Window = 0, 0
+		return nil
+	}
+	limit, window, found := strings.Cut(string(text), "/")
+	if !found {
+		return fmt.Errorf("rate limit %q must look like <requests>/<window>", text)
+	}
+	parsedLimit, err := strconv.Atoi(strings.TrimSpace(limit))
+	if err != nil || parsedLimit < 0 {
+		return fmt.Errorf("rate limit %q has invalid number of requests", text)
+	}
+	parsedWindow, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || parsedWindow <= 0 {
+		return fmt.Errorf("rate limit %q has invalid window", text)
+	}
+	r.Limit, r.Window = parsedLimit, parsedWindow
+	return nil
+}
+
+// PaymentsConfig настройки приема оплаты
+type PaymentsConfig struct {
+	Provider string `yaml:"provider"` // sandbox — песочница без списания денег
+}
+
+// Default возвращает настройки по умолчанию для локальной разработки
+func Default() *Config {
+	return &Config{
+		App: AppConfig{
+			URL:             "http://localhost:8080",
+			StartTimeout:    15 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		HTTP:    HTTPConfig{Addr: ":8080"},
+		Redis:   RedisConfig{Addr: "localhost:6379"},
+		Storage: StorageConfig{Backend: "local", LocalDir: "../storage", PrivateLocalDir: "../storage-private"},
+		Mail: MailConfig{
+			Mailer:  "file",
+			DumpDir: "../mail",
+			From:    "Marketplace <no-reply@localhost>",
+		},
+		Payments: PaymentsConfig{Provider: "sandbox"},
+	}
+}
+
+// Load собирает настройки из значений по умолчанию, YAML-файла, переменных окружения
+// и флагов командной строки args и проверяет их. Путь к YAML-файлу задается флагом
+// -config или переменной CONFIG_FILE, файл с переменными окружения — флагом -env-file.
+func Load(args []string) (*Config, error) {
+	flags := flag.NewFlagSet("marketplace", flag.ContinueOnError)
+	configFile := flags.String("config", "", "path to the YAML config file (or CONFIG_FILE)")
+	envFile := flags.String("env-file", "../.env", "path to the file with environment variables, ignored if missing")
+	httpAddr := flags.String("http-addr", "", "address the HTTP server listens on")
+	appEnv := flags.String("app-env", "", "application environment, Dev enables request logging")
+	redisAddr := flags.String("redis-addr", "", "Redis address")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// Переменные из файла не перекрывают уже заданные в окружении
+	if err := godotenv.Load(*envFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load %s: %v", *envFile, err)
+	}
+
+	cfg := Default()
+	if *configFile == "" {
+		*configFile = os.Getenv("CONFIG_FILE")
+	}
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "http-addr":
+			cfg.HTTP.Addr = *httpAddr
+		case "app-env":
+			cfg.App.Env = *appEnv
+		case "redis-addr":
+			cfg.Redis.Addr = *redisAddr
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile применяет настройки из YAML-файла. Неизвестные ключи считаются ошибкой,
+// чтобы опечатка не приводила к молчаливому использованию значения по умолчанию.
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %v", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err = decoder.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// loadEnv применяет заданные переменные окружения
+func (c *Config) loadEnv() error {
+	var errs []error
+	setString := func(name string, target *string) {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = value
+		}
+	}
+	setList := func(name string, target *[]string) {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = splitList(value)
+		}
+	}
+	setBool := func(name string, target *bool) {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be true or false, got %q", name, value))
+				return
+			}
+			*target = parsed
+		}
+	}
+	setInt := func(name string, target *int) {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be a number, got %q", name, value))
+				return
+			}
+			*target = parsed
+		}
+	}
+	setDuration := func(name string, target *time.Duration) {
+		if value, ok := os.LookupEnv(name); ok {
+			if value == "" {
+				*target = 0
+				return
+			}
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be a duration like 168h, got %q", name, value))
+				return
+			}
+			*target = parsed
+		}
+	}
+
+	setString("APP_ENV", &c.App.Env)
+	setString("APP_URL", &c.App.URL)
+	setList("ADMIN_EMAILS", &c.App.AdminEmails)
+	setDuration("START_TIMEOUT", &c.App.StartTimeout)
+	setDuration("SHUTDOWN_TIMEOUT", &c.App.ShutdownTimeout)
+	setString("HTTP_ADDR", &c.HTTP.Addr)
+	setList("TRUSTED_PROXIES", &c.HTTP.TrustedProxies)
+	setString("REDIS_ADDR", &c.Redis.Addr)
+	setString("REDIS_PASSWORD", &c.Redis.Password)
+	setInt("REDIS_DB", &c.Redis.DB)
+	setString("JWT_SECRET_KEY", &c.JWT.Secret)
+	setString("BLOB_STORAGE", &c.Storage.Backend)
+	setString("LOCAL_STORAGE_DIR", &c.Storage.LocalDir)
+	setString("LOCAL_PRIVATE_STORAGE_DIR", &c.Storage.PrivateLocalDir)
+	setString("S3_ENDPOINT", &c.Storage.S3.Endpoint)
+	setString("S3_ACCESS_KEY", &c.Storage.S3.AccessKey)
+	setString("S3_SECRET_KEY", &c.Storage.S3.SecretKey)
+	setString("S3_BUCKET", &c.Storage.S3.Bucket)
+	setString("S3_PRIVATE_BUCKET", &c.Storage.S3.PrivateBucket)
+	setBool("S3_USE_SSL", &c.Storage.S3.UseSSL)
+	setString("S3_PUBLIC_URL", &c.Storage.S3.PublicURL)
+	setString("MAILER", &c.Mail.Mailer)
+	setString("MAIL_DUMP_DIR", &c.Mail.DumpDir)
+	setString("MAIL_FROM", &c.Mail.From)
+	setString("SMTP_HOST", &c.Mail.SMTP.Host)
+	setString("SMTP_PORT", &c.Mail.SMTP.Port)
+	setString("SMTP_USERNAME", &c.Mail.SMTP.Username)
+	setString("SMTP_PASSWORD", &c.Mail.SMTP.Password)
+	setBool("REQUIRE_VERIFIED_SELLERS", &c.Security.RequireVerifiedSellers)
+	setList("REQUIRE_2FA_ROLES", &c.Security.Require2FARoles)
+	setList("MODERATION_STOP_WORDS", &c.Moderation.StopWords)
+	setList("WS_ALLOWED_ORIGINS", &c.Events.WSAllowedOrigins)
+	setString("EXCHANGE_RATES_FILE", &c.Ledger.ExchangeRatesFile)
+	setDuration("PAYOUT_INTERVAL", &c.Ledger.PayoutInterval)
+	setString("PAYMENT_PROVIDER", &c.Payments.Provider)
+
+	// Лимиты задаются переменными RATE_LIMIT_<ИМЯ ПОЛИТИКИ>
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		policy, ok := strings.CutPrefix(name, "RATE_LIMIT_")
+		if !ok || value == "" {
+			continue
+		}
+		var limit RateLimit
+		if err := limit.UnmarshalText([]byte(value)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+			continue
+		}
+		if c.RateLimits == nil {
+			c.RateLimits = make(map[string]RateLimit)
+		}
+		c.RateLimits[strings.ToLower(policy)] = limit
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate проверяет настройки и возвращает все найденные ошибки сразу
+func (c *Config) Validate() error {
+	var errs []error
+	required := func(value, name string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, fmt.Errorf("%s is required", name))
+		}
+	}
+
+	required(c.HTTP.Addr, "http.addr (HTTP_ADDR)")
+	if _, err := c.HTTP.TrustedProxyNets(); err != nil {
+		errs = append(errs, fmt.Errorf("http.trusted_proxies (TRUSTED_PROXIES): %v", err))
+	}
+	required(c.Redis.Addr, "redis.addr (REDIS_ADDR)")
+	required(c.JWT.Secret, "jwt.secret (JWT_SECRET_KEY)")
+	if appURL, err := url.Parse(c.App.URL); err != nil || appURL.Scheme == "" || appURL.Host == "" {
+		errs = append(errs, fmt.Errorf("app.url (APP_URL) must be an absolute URL, got %q", c.App.URL))
+	}
+	if c.App.StartTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("app.start_timeout (START_TIMEOUT) must be positive"))
+	}
+	if c.App.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("app.shutdown_timeout (SHUTDOWN_TIMEOUT) must be positive"))
+	}
+
+	switch c.Storage.Backend {
+	case "local":
+		required(c.Storage.LocalDir, "storage.local_dir (LOCAL_STORAGE_DIR)")
+		required(c.Storage.PrivateLocalDir, "storage.private_local_dir (LOCAL_PRIVATE_STORAGE_DIR)")
+		if filepath.Clean(c.Storage.PrivateLocalDir) == filepath.Clean(c.Storage.LocalDir) {
+			errs = append(errs, fmt.Errorf("storage.private_local_dir (LOCAL_PRIVATE_STORAGE_DIR) must differ from storage.local_dir"))
+		}
+	case "s3":
+		required(c.Storage.S3.Endpoint, "storage.s3.endpoint (S3_ENDPOINT)")
+		required(c.Storage.S3.Bucket, "storage.s3.bucket (S3_BUCKET)")
+		if c.Storage.S3.PrivateBucket != "" && c.Storage.S3.PrivateBucket == c.Storage.S3.Bucket {
+			errs = append(errs, fmt.Errorf("storage.s3.private_bucket (S3_PRIVATE_BUCKET) must differ from storage.s3.bucket"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("storage.backend (BLOB_STORAGE) must be local or s3, got %q", c.Storage.Backend))
+	}
+
+	required(c.Mail.From, "mail.from (MAIL_FROM)")
+	switch c.Mail.Mailer {
+	case "file":
+		required(c.Mail.DumpDir, "mail.dump_dir (MAIL_DUMP_DIR)")
+	case "smtp":
+		required(c.Mail.SMTP.Host, "mail.smtp.host (SMTP_HOST)")
+		required(c.Mail.SMTP.Port, "mail.smtp.port (SMTP_PORT)")
+	default:
+		errs = append(errs, fmt.Errorf("mail.mailer (MAILER) must be file or smtp, got %q", c.Mail.Mailer))
+	}
+
+	for _, role := range c.Security.Require2FARoles {
+		if role != "admin" && role != "seller" {
+			errs = append(errs, fmt.Errorf("security.require_2fa_roles (REQUIRE_2FA_ROLES) may contain admin and seller, got %q", role))
+		}
+	}
+	if c.Ledger.PayoutInterval < 0 {
+		errs = append(errs, fmt.Errorf("ledger.payout_interval (PAYOUT_INTERVAL) must not be negative"))
+	}
+	if c.Payments.Provider != "sandbox" {
+		errs = append(errs, fmt.Errorf("payments.provider (PAYMENT_PROVIDER) must be sandbox, got %q", c.Payments.Provider))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// AddressHandler обрабатывает HTTP-запросы для адресной книги текущего пользователя
+type AddressHandler struct {
+	addressUseCase *usecase.AddressUseCase
+}
+
+// NewAddressHandler создает новый экземпляр AddressHandler
+func NewAddressHandler(addressUseCase *usecase.AddressUseCase) *AddressHandler {
+	return &AddressHandler{addressUseCase: addressUseCase}
+}
+
+// CreateAddress обрабатывает запрос на добавление адреса
+func (h *AddressHandler) CreateAddress(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var address entities.Address
+	if err := c.Bind(&address); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	address, err = h.addressUseCase.CreateAddress(userID, address)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, address)
+}
+
+// GetAddresses обрабатывает запрос на получение всех адресов пользователя
+func (h *AddressHandler) GetAddresses(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	addresses, err := h.addressUseCase.GetAddresses(userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, addresses)
+}
+
+// GetAddress обрабатывает запрос на получение адреса по ID
+func (h *AddressHandler) GetAddress(c echo.Context) error {
+	addressID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	address, err := h.addressUseCase.GetAddress(userID, addressID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, address)
+}
+
+// UpdateAddress обрабатывает запрос на обновление адреса
+func (h *AddressHandler) UpdateAddress(c echo.Context) error {
+	addressID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var address entities.Address
+	if err := c.Bind(&address); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	address.ID = addressID
+
+	address, err = h.addressUseCase.UpdateAddress(userID, address)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, address)
+}
+
+// DeleteAddress обрабатывает запрос на удаление адреса
+func (h *AddressHandler) DeleteAddress(c echo.Context) error {
+	addressID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.addressUseCase.DeleteAddress(userID, addressID); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"net/http"
+	"strconv"
+)
+
+// CategoryHandler обрабатывает HTTP-запросы для категорий
+type CategoryHandler struct {
+	categoryUseCase *usecase.CategoryUseCase
+	currencyUseCase *usecase.CurrencyUseCase
+}
+
+// NewCategoryHandler создает новый экземпляр CategoryHandler
+func NewCategoryHandler(categoryUseCase *usecase.CategoryUseCase, currencyUseCase *usecase.CurrencyUseCase) *CategoryHandler {
+	return &CategoryHandler{categoryUseCase: categoryUseCase, currencyUseCase: currencyUseCase}
+}
+
+// CreateCategory обрабатывает запрос на создание категории
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	var category entities.Category
+
+	if err := c.Bind(&category); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := h.categoryUseCase.CreateCategory(category); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory обрабатывает запрос на обновление категории
+func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
+	id := c.Param("id")
+	uint64ID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var category entities.Category
+	if err := c.Bind(&category); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	category.ID = uint64ID
+
+	if err := h.categoryUseCase.UpdateCategory(category); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory обрабатывает запрос на удаление категории
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	id := c.Param("id")
+	uint64ID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if err := h.categoryUseCase.DeleteCategory(uint64ID); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetCategoryTree обрабатывает запрос на получение дерева категорий
+func (h *CategoryHandler) GetCategoryTree(c echo.Context) error {
+	tree, err := h.categoryUseCase.GetCategoryTree()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tree)
+}
+
+// GetProductsByCategory обрабатывает запрос на получение продуктов категории и ее потомков
+func (h *CategoryHandler) GetProductsByCategory(c echo.Context) error {
+	products, err := h.categoryUseCase.GetProductsBySlug(c.Param("slug"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+	products, err = h.currencyUseCase.ConvertProducts(products, requestedCurrency(c))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, products)
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// ConversationHandler обрабатывает HTTP-запросы для переписки покупателей с магазинами
+type ConversationHandler struct {
+	conversationUseCase *usecase.ConversationUseCase
+}
+
+// NewConversationHandler создает новый экземпляр ConversationHandler
+func NewConversationHandler(conversationUseCase *usecase.ConversationUseCase) *ConversationHandler {
+	return &ConversationHandler{conversationUseCase: conversationUseCase}
+}
+
+// StartConversation обрабатывает запрос на начало переписки с магазином
+func (h *ConversationHandler) StartConversation(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var request entities.StartConversationRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	conversation, err := h.conversationUseCase.StartConversation(userID, request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, conversation)
+}
+
+// GetConversations обрабатывает запрос на получение переписок пользователя
+func (h *ConversationHandler) GetConversations(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	conversations, err := h.conversationUseCase.GetConversations(userID, page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, conversations)
+}
+
+// GetUnreadCount обрабатывает запрос на получение количества непрочитанных сообщений
+func (h *ConversationHandler) GetUnreadCount(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	count, err := h.conversationUseCase.GetUnreadCount(userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, count)
+}
+
+// GetMessages обрабатывает запрос на получение сообщений переписки
+func (h *ConversationHandler) GetMessages(c echo.Context) error {
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	messages, err := h.conversationUseCase.GetMessages(conversationID, userID, page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, messages)
+}
+
+// SendMessage обрабатывает запрос на отправку текстового сообщения
+func (h *ConversationHandler) SendMessage(c echo.Context) error {
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var message entities.Message
+	if err := c.Bind(&message); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	message, err = h.conversationUseCase.SendMessage(conversationID, userID, message)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, message)
+}
+
+// SendAttachment обрабатывает multipart-загрузку вложения в поле file с необязательным полем text
+func (h *ConversationHandler) SendAttachment(c echo.Context) error {
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	data, err := readFormFile(c, "file", constants.MaxMessageAttachmentSize)
+	if err != nil {
+		return c.JSON(uploadErrorStatus(err), echo.Map{"error": err.Error()})
+	}
+
+	message, err := h.conversationUseCase.SendAttachment(conversationID, userID, c.FormValue("text"), data)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, message)
+}
+
+// GetAttachment отдает вложение переписки ее участнику
+func (h *ConversationHandler) GetAttachment(c echo.Context) error {
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	data, contentType, err := h.conversationUseCase.GetAttachment(conversationID, userID, c.Param("name"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, "private, no-store")
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// MarkRead обрабатывает запрос на отметку сообщений переписки прочитанными
+func (h *ConversationHandler) MarkRead(c echo.Context) error {
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.conversationUseCase.MarkRead(conversationID, userID); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"net/http"
+)
+
+// CurrencyHandler обрабатывает HTTP-запросы для курсов валют
+type CurrencyHandler struct {
+	currencyUseCase *usecase.CurrencyUseCase
+}
+
+// NewCurrencyHandler создает новый экземпляр CurrencyHandler
+func NewCurrencyHandler(currencyUseCase *usecase.CurrencyUseCase) *CurrencyHandler {
+	return &CurrencyHandler{currencyUseCase: currencyUseCase}
+}
+
+// AddExchangeRate обрабатывает запрос на добавление курса валюты
+func (h *CurrencyHandler) AddExchangeRate(c echo.Context) error {
+	var rate entities.ExchangeRate
+
+	if err := c.Bind(&rate); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := h.currencyUseCase.AddRate(rate); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, rate)
+}
+
+// GetExchangeRates обрабатывает запрос на получение всех курсов валют
+func (h *CurrencyHandler) GetExchangeRates(c echo.Context) error {
+	rates, err := h.currencyUseCase.GetRates()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
+
+// requestedCurrency возвращает валюту отображения из параметра currency или заголовка Accept-Currency
+func requestedCurrency(c echo.Context) string {
+	if currency := c.QueryParam("currency"); currency != "" {
+		return currency
+	}
+	return c.Request().Header.Get("Accept-Currency")
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// writeTimeout сколько ждать отправки одного кадра клиенту
+const writeTimeout = 10 * time.Second
+
+// EventHandler доставляет события реального времени клиентам
+type EventHandler struct {
+	eventUseCase *usecase.EventUseCase
+	upgrader     websocket.Upgrader
+	shutdown     <-chan struct{}
+}
+
+// NewEventHandler создает новый экземпляр EventHandler. Пустой allowedOrigins
+// разрешает подключения только с того же origin, что и сервер. После закрытия
+// shutdown открытые потоки завершаются, чтобы не задерживать остановку сервера.
+func NewEventHandler(eventUseCase *usecase.EventUseCase, allowedOrigins []string, shutdown <-chan struct{}) *EventHandler {
+	upgrader := websocket.Upgrader{}
+	if len(allowedOrigins) > 0 {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			return slices.Contains(allowedOrigins, r.Header.Get("Origin"))
+		}
+	}
+	return &EventHandler{eventUseCase: eventUseCase, upgrader: upgrader, shutdown: shutdown}
+}
+
+// UserEvents обрабатывает WebSocket-подключение к личному каналу событий пользователя.
+// Параметр last_event_id возобновляет доставку после переподключения.
+func (h *EventHandler) UserEvents(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	events, err := h.eventUseCase.Stream(ctx, entities.UserTopic(userID), c.QueryParam("last_event_id"))
+	if err != nil {
+		return c.JSON(streamErrorStatus(err), echo.Map{"error": err.Error()})
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// Upgrader уже отправил клиенту ответ с ошибкой
+		return nil
+	}
+	defer conn.Close()
+
+	// Чтение нужно для обработки pong и закрытия соединения клиентом
+	_ = conn.SetReadDeadline(time.Now().Add(constants.EventPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(constants.EventPongTimeout))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(constants.EventHeartbeatPeriod)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Клиент отстал или сервер останавливается: клиент переподключится с last_event_id
+				message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "reconnect")
+				_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeTimeout))
+				return nil
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return nil
+			}
+		case <-h.shutdown:
+			message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+			_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeTimeout))
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// StoreEvents отдает владельцу поток событий магазина в формате Server-Sent Events.
+// Заголовок Last-Event-ID (или параметр last_event_id) возобновляет доставку.
+func (h *EventHandler) StoreEvents(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	events, err := h.eventUseCase.StreamStoreEvents(ctx, storeID, userID, lastEventID)
+	if err != nil {
+		return c.JSON(streamErrorStatus(err), echo.Map{"error": err.Error()})
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set(echo.HeaderConnection, "keep-alive")
+	response.Header().Set("X-Accel-Buffering", "no") // Отключает буферизацию в nginx
+	response.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(response, "retry: %d\n\n", constants.EventRetryInterval.Milliseconds()); err != nil {
+		return nil
+	}
+	response.Flush()
+
+	keepAlive := time.NewTicker(constants.EventKeepAlivePeriod)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Клиент сам переподключится и передаст Last-Event-ID
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return nil
+			}
+			if _, err := fmt.Fprintf(response, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(response, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case <-h.shutdown:
+			// Клиент переподключится к другому экземпляру с Last-Event-ID
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+		response.Flush()
+	}
+}
+
+// streamErrorStatus выбирает HTTP-статус для ошибки подписки на события.
+// Остальные ошибки означают, что магазин не найден.
+func streamErrorStatus(err error) int {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrEventsUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusNotFound
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// InvoiceHandler обрабатывает HTTP-запросы для счетов магазинов
+type InvoiceHandler struct {
+	invoiceUseCase *usecase.InvoiceUseCase
+}
+
+// NewInvoiceHandler создает новый экземпляр InvoiceHandler
+func NewInvoiceHandler(invoiceUseCase *usecase.InvoiceUseCase) *InvoiceHandler {
+	return &InvoiceHandler{invoiceUseCase: invoiceUseCase}
+}
+
+// IssueInvoice обрабатывает запрос владельца магазина на выставление счета
+func (h *InvoiceHandler) IssueInvoice(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var input entities.InvoiceInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	invoice, err := h.invoiceUseCase.IssueInvoice(storeID, userID, input)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, invoice)
+}
+
+// GetStoreInvoices обрабатывает запрос владельца на получение счетов магазина
+func (h *InvoiceHandler) GetStoreInvoices(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	invoices, err := h.invoiceUseCase.GetStoreInvoices(storeID, userID, page, perPage)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, invoices)
+}
+
+// GetInvoice обрабатывает запрос покупателя или продавца на получение счета
+func (h *InvoiceHandler) GetInvoice(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	invoice, err := h.invoiceUseCase.GetInvoice(id, userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, invoice)
+}
+
+// GetInvoiceDocument отдает PDF счета покупателю или продавцу
+func (h *InvoiceHandler) GetInvoiceDocument(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	data, contentType, err := h.invoiceUseCase.GetInvoiceDocument(id, userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, "private, no-store")
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	return c.Blob(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// LedgerHandler обрабатывает HTTP-запросы для расчетов с продавцами
+type LedgerHandler struct {
+	ledgerUseCase *usecase.LedgerUseCase
+}
+
+// NewLedgerHandler создает новый экземпляр LedgerHandler
+func NewLedgerHandler(ledgerUseCase *usecase.LedgerUseCase) *LedgerHandler {
+	return &LedgerHandler{ledgerUseCase: ledgerUseCase}
+}
+
+// GetStoreBalance обрабатывает запрос владельца на получение остатка расчетов магазина
+func (h *LedgerHandler) GetStoreBalance(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	balance, err := h.ledgerUseCase.GetStoreBalance(storeID, userID)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, balance)
+}
+
+// GetStoreTransactions обрабатывает запрос владельца на получение движений по магазину
+func (h *LedgerHandler) GetStoreTransactions(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	transactions, err := h.ledgerUseCase.GetStoreTransactions(storeID, userID, page, perPage)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, transactions)
+}
+
+// CreateCommissionRule обрабатывает запрос на создание правила комиссии
+func (h *LedgerHandler) CreateCommissionRule(c echo.Context) error {
+	var rule entities.CommissionRule
+
+	if err := c.Bind(&rule); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	rule, err := h.ledgerUseCase.CreateCommissionRule(rule)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, rule)
+}
+
+// GetCommissionRules обрабатывает запрос на получение правил комиссии
+func (h *LedgerHandler) GetCommissionRules(c echo.Context) error {
+	rules, err := h.ledgerUseCase.GetCommissionRules()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+// DeleteCommissionRule обрабатывает запрос на удаление правила комиссии
+func (h *LedgerHandler) DeleteCommissionRule(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if err := h.ledgerUseCase.DeleteCommissionRule(id); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreatePayoutBatch обрабатывает запрос на формирование пакета выплат продавцам
+func (h *LedgerHandler) CreatePayoutBatch(c echo.Context) error {
+	batch, err := h.ledgerUseCase.CreatePayoutBatch()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+	if len(batch.Payouts) == 0 {
+		return c.JSON(http.StatusOK, batch)
+	}
+
+	return c.JSON(http.StatusCreated, batch)
+}
+
+// GetPayoutBatches обрабатывает запрос на получение пакетов выплат
+func (h *LedgerHandler) GetPayoutBatches(c echo.Context) error {
+	batches, err := h.ledgerUseCase.GetPayoutBatches()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, batches)
+}
+
+// Reconcile обрабатывает запрос на сверку журнала за период from..to в формате RFC 3339
+func (h *LedgerHandler) Reconcile(c echo.Context) error {
+	var from time.Time
+	to := time.Now()
+	if value := c.QueryParam("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid from"})
+		}
+		from = parsed
+	}
+	if value := c.QueryParam("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid to"})
+		}
+		to = parsed
+	}
+
+	report, err := h.ledgerUseCase.Reconcile(from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// NotificationHandler обрабатывает HTTP-запросы для уведомлений текущего пользователя
+type NotificationHandler struct {
+	notificationUseCase *usecase.NotificationUseCase
+}
+
+// NewNotificationHandler создает новый экземпляр NotificationHandler
+func NewNotificationHandler(notificationUseCase *usecase.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{notificationUseCase: notificationUseCase}
+}
+
+// GetNotifications обрабатывает запрос на получение уведомлений пользователя
+func (h *NotificationHandler) GetNotifications(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	notifications, err := h.notificationUseCase.GetNotifications(userID, page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead обрабатывает запрос на отметку уведомления прочитанным
+func (h *NotificationHandler) MarkNotificationRead(c echo.Context) error {
+	notificationID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.notificationUseCase.MarkRead(userID, notificationID); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetPreferences обрабатывает запрос на получение настроек уведомлений пользователя
+func (h *NotificationHandler) GetPreferences(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	preferences, err := h.notificationUseCase.GetPreferences(userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, preferences)
+}
+
+// UpdatePreferences обрабатывает запрос на изменение настроек уведомлений пользователя
+func (h *NotificationHandler) UpdatePreferences(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var preferences entities.NotificationPreferences
+	if err := c.Bind(&preferences); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	preferences, err = h.notificationUseCase.UpdatePreferences(userID, preferences)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, preferences)
+}
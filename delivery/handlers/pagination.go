@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/pkg/constants"
+	"strconv"
+)
+
+// paginationParams извлекает page и per_page из запроса, подставляя значения по умолчанию
+func paginationParams(c echo.Context) (int, int) {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = constants.DefaultPageSize
+	}
+	return page, min(perPage, constants.MaxPageSize)
+}
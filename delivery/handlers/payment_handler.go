@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// PaymentHandler обрабатывает HTTP-запросы для оплаты счетов
+type PaymentHandler struct {
+	paymentUseCase *usecase.PaymentUseCase
+}
+
+// NewPaymentHandler создает новый экземпляр PaymentHandler
+func NewPaymentHandler(paymentUseCase *usecase.PaymentUseCase) *PaymentHandler {
+	return &PaymentHandler{paymentUseCase: paymentUseCase}
+}
+
+// PayInvoice обрабатывает оплату счета покупателем
+func (h *PaymentHandler) PayInvoice(c echo.Context) error {
+	invoiceID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var input entities.PaymentInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	payment, err := h.paymentUseCase.PayInvoice(invoiceID, userID, input)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, payment)
+}
+
+// GetInvoicePayment обрабатывает запрос покупателя или продавца на получение оплаты счета
+func (h *PaymentHandler) GetInvoicePayment(c echo.Context) error {
+	invoiceID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	payment, err := h.paymentUseCase.GetInvoicePayment(invoiceID, userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, payment)
+}
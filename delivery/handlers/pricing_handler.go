@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+)
+
+// PricingHandler обрабатывает HTTP-запросы на расчет стоимости корзины
+type PricingHandler struct {
+	pricingUseCase *usecase.PricingUseCase
+}
+
+// NewPricingHandler создает новый экземпляр PricingHandler
+func NewPricingHandler(pricingUseCase *usecase.PricingUseCase) *PricingHandler {
+	return &PricingHandler{pricingUseCase: pricingUseCase}
+}
+
+// Quote обрабатывает запрос на расчет стоимости корзины со скидками
+func (h *PricingHandler) Quote(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var request entities.PricingRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	cart, err := h.pricingUseCase.Quote(userID, request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
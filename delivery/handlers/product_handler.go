@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/labstack/echo/v4"
 	"marketplace/internal/domain/entities"
 	"marketplace/internal/domain/usecase"
@@ -10,12 +11,13 @@ import (
 
 // ProductHandler обрабатывает HTTP-запросы для продуктов
 type ProductHandler struct {
-	productUseCase *usecase.ProductUseCase
+	productUseCase  *usecase.ProductUseCase
+	currencyUseCase *usecase.CurrencyUseCase
 }
 
 // NewProductHandler создает новый экземпляр ProductHandler
-func NewProductHandler(productUseCase *usecase.ProductUseCase) *ProductHandler {
-	return &ProductHandler{productUseCase: productUseCase}
+func NewProductHandler(productUseCase *usecase.ProductUseCase, currencyUseCase *usecase.CurrencyUseCase) *ProductHandler {
+	return &ProductHandler{productUseCase: productUseCase, currencyUseCase: currencyUseCase}
 }
 
 // CreateProduct обрабатывает запрос на создание продукта
@@ -26,7 +28,10 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
 	}
 
-	if err := h.productUseCase.CreateProduct(product); err != nil {
+	var validationErr *usecase.ValidationError
+	if err := h.productUseCase.CreateProduct(product); errors.As(err, &validationErr) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	} else if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
 	}
 
@@ -44,6 +49,10 @@ func (h *ProductHandler) GetProductByID(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
 	}
+	product, err = h.currencyUseCase.ConvertProduct(product, requestedCurrency(c))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
 
 	return c.JSON(http.StatusOK, product)
 }
@@ -56,7 +65,10 @@ func (h *ProductHandler) UpdateProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
 	}
 
-	if err := h.productUseCase.UpdateProduct(product); err != nil {
+	var validationErr *usecase.ValidationError
+	if err := h.productUseCase.UpdateProduct(product); errors.As(err, &validationErr) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	} else if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
 	}
 
@@ -88,6 +100,10 @@ func (h *ProductHandler) GetProductsByStore(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
 	}
+	products, err = h.currencyUseCase.ConvertProducts(products, requestedCurrency(c))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
 
 	return c.JSON(http.StatusOK, products)
 }
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// ProductImageHandler обрабатывает HTTP-запросы для изображений продуктов
+type ProductImageHandler struct {
+	imageUseCase *usecase.ProductImageUseCase
+}
+
+// NewProductImageHandler создает новый экземпляр ProductImageHandler
+func NewProductImageHandler(imageUseCase *usecase.ProductImageUseCase) *ProductImageHandler {
+	return &ProductImageHandler{imageUseCase: imageUseCase}
+}
+
+// UploadImage обрабатывает multipart-загрузку изображения продукта в поле image
+func (h *ProductImageHandler) UploadImage(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	data, err := readFormFile(c, "image", constants.MaxProductImageSize)
+	if err != nil {
+		return c.JSON(uploadErrorStatus(err), echo.Map{"error": err.Error()})
+	}
+
+	img, err := h.imageUseCase.AddImage(productID, userID, data)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, img)
+}
+
+// DeleteImage обрабатывает запрос на удаление изображения продукта
+func (h *ProductImageHandler) DeleteImage(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	err = h.imageUseCase.DeleteImage(productID, userID, c.Param("image_id"))
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// PromotionHandler обрабатывает HTTP-запросы для промокодов и акций магазинов
+type PromotionHandler struct {
+	promotionUseCase *usecase.PromotionUseCase
+}
+
+// NewPromotionHandler создает новый экземпляр PromotionHandler
+func NewPromotionHandler(promotionUseCase *usecase.PromotionUseCase) *PromotionHandler {
+	return &PromotionHandler{promotionUseCase: promotionUseCase}
+}
+
+// CreateCoupon обрабатывает запрос на создание промокода магазина
+func (h *PromotionHandler) CreateCoupon(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var coupon entities.Coupon
+	if err := c.Bind(&coupon); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	coupon, err = h.promotionUseCase.CreateCoupon(storeID, userID, coupon)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, coupon)
+}
+
+// GetStoreCoupons обрабатывает запрос владельца на получение промокодов магазина
+func (h *PromotionHandler) GetStoreCoupons(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	coupons, err := h.promotionUseCase.GetStoreCoupons(storeID, userID)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, coupons)
+}
+
+// DeleteCoupon обрабатывает запрос на удаление промокода
+func (h *PromotionHandler) DeleteCoupon(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	err = h.promotionUseCase.DeleteCoupon(id, userID)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreatePromotion обрабатывает запрос на создание автоматической акции магазина
+func (h *PromotionHandler) CreatePromotion(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var promotion entities.Promotion
+	if err := c.Bind(&promotion); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	promotion, err = h.promotionUseCase.CreatePromotion(storeID, userID, promotion)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, promotion)
+}
+
+// GetStorePromotions обрабатывает запрос на получение акций магазина
+func (h *PromotionHandler) GetStorePromotions(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	promotions, err := h.promotionUseCase.GetStorePromotions(storeID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, promotions)
+}
+
+// DeletePromotion обрабатывает запрос на удаление акции
+func (h *PromotionHandler) DeletePromotion(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	err = h.promotionUseCase.DeletePromotion(id, userID)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
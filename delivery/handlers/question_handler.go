@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// QuestionHandler обрабатывает HTTP-запросы для вопросов и ответов о продуктах
+type QuestionHandler struct {
+	questionUseCase *usecase.QuestionUseCase
+}
+
+// NewQuestionHandler создает новый экземпляр QuestionHandler
+func NewQuestionHandler(questionUseCase *usecase.QuestionUseCase) *QuestionHandler {
+	return &QuestionHandler{questionUseCase: questionUseCase}
+}
+
+// AskQuestion обрабатывает запрос на создание вопроса о продукте
+func (h *QuestionHandler) AskQuestion(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var question entities.Question
+	if err := c.Bind(&question); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	question, err = h.questionUseCase.AskQuestion(productID, userID, question)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, question)
+}
+
+// GetProductQuestions обрабатывает запрос на получение страницы вопросов о продукте
+func (h *QuestionHandler) GetProductQuestions(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	questions, err := h.questionUseCase.GetProductQuestions(productID, userID, page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, questions)
+}
+
+// AnswerQuestion обрабатывает запрос продавца на ответ к вопросу
+func (h *QuestionHandler) AnswerQuestion(c echo.Context) error {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var answer entities.Answer
+	if err := c.Bind(&answer); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	answer, err = h.questionUseCase.AnswerQuestion(questionID, userID, answer)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, answer)
+}
+
+// GetAnswers обрабатывает запрос на получение ответов на вопрос
+func (h *QuestionHandler) GetAnswers(c echo.Context) error {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	answers, err := h.questionUseCase.GetAnswers(questionID, userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, answers)
+}
+
+// UpvoteAnswer обрабатывает запрос на отметку ответа полезным
+func (h *QuestionHandler) UpvoteAnswer(c echo.Context) error {
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	answer, err := h.questionUseCase.UpvoteAnswer(answerID, userID)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, answer)
+}
+
+// RemoveUpvote обрабатывает запрос на отмену отметки «полезно»
+func (h *QuestionHandler) RemoveUpvote(c echo.Context) error {
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	answer, err := h.questionUseCase.RemoveUpvote(answerID, userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, answer)
+}
+
+// GetModerationQuestions обрабатывает запрос администратора на очередь модерации вопросов.
+// Параметр status выбирает статус (по умолчанию pending).
+func (h *QuestionHandler) GetModerationQuestions(c echo.Context) error {
+	page, perPage := paginationParams(c)
+	questions, err := h.questionUseCase.GetModerationQuestions(moderationStatusParam(c), page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, questions)
+}
+
+// GetModerationAnswers обрабатывает запрос администратора на очередь модерации ответов.
+// Параметр status выбирает статус (по умолчанию pending).
+func (h *QuestionHandler) GetModerationAnswers(c echo.Context) error {
+	page, perPage := paginationParams(c)
+	answers, err := h.questionUseCase.GetModerationAnswers(moderationStatusParam(c), page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, answers)
+}
+
+// moderationStatusParam возвращает статус модерации из параметра status, по умолчанию pending
+func moderationStatusParam(c echo.Context) enums.ModerationStatus {
+	if status := c.QueryParam("status"); status != "" {
+		return enums.ModerationStatus(status)
+	}
+	return enums.PendingContent
+}
+
+// ModerateQuestion обрабатывает решение администратора по вопросу
+func (h *QuestionHandler) ModerateQuestion(c echo.Context) error {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var decision entities.ModerationDecision
+	if err := c.Bind(&decision); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	question, err := h.questionUseCase.ModerateQuestion(questionID, decision)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, question)
+}
+
+// ModerateAnswer обрабатывает решение администратора по ответу
+func (h *QuestionHandler) ModerateAnswer(c echo.Context) error {
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var decision entities.ModerationDecision
+	if err := c.Bind(&decision); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	answer, err := h.questionUseCase.ModerateAnswer(answerID, decision)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, answer)
+}
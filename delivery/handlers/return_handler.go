@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// ReturnHandler обрабатывает HTTP-запросы для возвратов товаров
+type ReturnHandler struct {
+	returnUseCase *usecase.ReturnUseCase
+}
+
+// NewReturnHandler создает новый экземпляр ReturnHandler
+func NewReturnHandler(returnUseCase *usecase.ReturnUseCase) *ReturnHandler {
+	return &ReturnHandler{returnUseCase: returnUseCase}
+}
+
+// RequestReturn обрабатывает заявку покупателя на возврат позиции счета
+func (h *ReturnHandler) RequestReturn(c echo.Context) error {
+	invoiceID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var input entities.ReturnRequestInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	request, err := h.returnUseCase.RequestReturn(invoiceID, userID, input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, request)
+}
+
+// AddReturnPhoto обрабатывает multipart-загрузку фотографии к заявке в поле photo
+func (h *ReturnHandler) AddReturnPhoto(c echo.Context) error {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	data, err := readFormFile(c, "photo", constants.MaxProductImageSize)
+	if err != nil {
+		return c.JSON(uploadErrorStatus(err), echo.Map{"error": err.Error()})
+	}
+
+	request, err := h.returnUseCase.AddPhoto(returnID, userID, data)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// GetReturnPhoto отдает фотографию заявки покупателю или продавцу
+func (h *ReturnHandler) GetReturnPhoto(c echo.Context) error {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	data, contentType, err := h.returnUseCase.GetPhoto(returnID, userID, c.Param("name"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, "private, no-store")
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// GetReturn обрабатывает запрос покупателя или продавца на получение заявки
+func (h *ReturnHandler) GetReturn(c echo.Context) error {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	request, err := h.returnUseCase.GetReturn(returnID, userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// GetUserReturns обрабатывает запрос на получение заявок текущего пользователя
+func (h *ReturnHandler) GetUserReturns(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	requests, err := h.returnUseCase.GetUserReturns(userID, page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, requests)
+}
+
+// GetStoreReturns обрабатывает запрос владельца на получение заявок на возврат в магазин
+func (h *ReturnHandler) GetStoreReturns(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	page, perPage := paginationParams(c)
+	requests, err := h.returnUseCase.GetStoreReturns(storeID, userID, page, perPage)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, requests)
+}
+
+// ApproveReturn обрабатывает одобрение заявки продавцом
+func (h *ReturnHandler) ApproveReturn(c echo.Context) error {
+	return h.decide(c, h.returnUseCase.ApproveReturn)
+}
+
+// RejectReturn обрабатывает отказ продавца в возврате
+func (h *ReturnHandler) RejectReturn(c echo.Context) error {
+	return h.decide(c, h.returnUseCase.RejectReturn)
+}
+
+// ShipReturn обрабатывает отправку товара покупателем
+func (h *ReturnHandler) ShipReturn(c echo.Context) error {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var shipment entities.ReturnShipment
+	if err := c.Bind(&shipment); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	request, err := h.returnUseCase.ShipReturn(returnID, userID, shipment)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// ReceiveReturn обрабатывает получение возвращенного товара продавцом
+func (h *ReturnHandler) ReceiveReturn(c echo.Context) error {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	request, err := h.returnUseCase.ReceiveReturn(returnID, userID)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// RefundReturn обрабатывает возврат денег покупателю продавцом
+func (h *ReturnHandler) RefundReturn(c echo.Context) error {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var input entities.ReturnRefundInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	request, err := h.returnUseCase.RefundReturn(returnID, userID, input)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// decide обрабатывает решение продавца по заявке
+func (h *ReturnHandler) decide(
+	c echo.Context,
+	decide func(returnID, userID uint64, decision entities.ReturnDecision) (entities.ReturnRequest, error),
+) error {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var decision entities.ReturnDecision
+	if err := c.Bind(&decision); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	request, err := decide(returnID, userID, decision)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// ReviewHandler обрабатывает HTTP-запросы для отзывов
+type ReviewHandler struct {
+	reviewUseCase *usecase.ReviewUseCase
+}
+
+// NewReviewHandler создает новый экземпляр ReviewHandler
+func NewReviewHandler(reviewUseCase *usecase.ReviewUseCase) *ReviewHandler {
+	return &ReviewHandler{reviewUseCase: reviewUseCase}
+}
+
+// CreateReview обрабатывает запрос на создание отзыва о продукте
+func (h *ReviewHandler) CreateReview(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var review entities.Review
+	if err := c.Bind(&review); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	review, err = h.reviewUseCase.CreateReview(productID, userID, review)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, review)
+}
+
+// GetProductReviews обрабатывает запрос на получение отзывов о продукте.
+// Поддерживает параметры sort, page и per_page.
+func (h *ReviewHandler) GetProductReviews(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	page, perPage := paginationParams(c)
+	reviews, err := h.reviewUseCase.GetProductReviews(productID, c.QueryParam("sort"), page, perPage)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, reviews)
+}
+
+// ReplyToReview обрабатывает запрос продавца на ответ к отзыву
+func (h *ReviewHandler) ReplyToReview(c echo.Context) error {
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var reply entities.ReviewReply
+	if err := c.Bind(&reply); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	review, err := h.reviewUseCase.ReplyToReview(reviewID, userID, reply)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+// AddReviewPhoto обрабатывает multipart-загрузку фотографии к отзыву в поле photo
+func (h *ReviewHandler) AddReviewPhoto(c echo.Context) error {
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	data, err := readFormFile(c, "photo", constants.MaxProductImageSize)
+	if err != nil {
+		return c.JSON(uploadErrorStatus(err), echo.Map{"error": err.Error()})
+	}
+
+	review, err := h.reviewUseCase.AddPhoto(reviewID, userID, data)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
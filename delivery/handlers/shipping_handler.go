@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// ShippingHandler обрабатывает HTTP-запросы для доставки
+type ShippingHandler struct {
+	shippingUseCase *usecase.ShippingUseCase
+}
+
+// NewShippingHandler создает новый экземпляр ShippingHandler
+func NewShippingHandler(shippingUseCase *usecase.ShippingUseCase) *ShippingHandler {
+	return &ShippingHandler{shippingUseCase: shippingUseCase}
+}
+
+// SaveShippingProfile обрабатывает запрос на сохранение профиля доставки магазина
+func (h *ShippingHandler) SaveShippingProfile(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var profile entities.ShippingProfile
+	if err := c.Bind(&profile); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	profile, err = h.shippingUseCase.SaveProfile(storeID, userID, profile)
+	if errors.Is(err, usecase.ErrForbidden) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// GetShippingProfile обрабатывает запрос на получение профиля доставки магазина
+func (h *ShippingHandler) GetShippingProfile(c echo.Context) error {
+	storeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	profile, err := h.shippingUseCase.GetProfile(storeID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// Quote обрабатывает запрос на расчет доступных способов доставки корзины
+func (h *ShippingHandler) Quote(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var request entities.ShippingQuoteRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	quotes, err := h.shippingUseCase.Quote(userID, request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, quotes)
+}
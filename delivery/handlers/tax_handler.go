@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"net/http"
+	"strconv"
+)
+
+// TaxHandler обрабатывает HTTP-запросы для налоговых ставок
+type TaxHandler struct {
+	taxUseCase *usecase.TaxUseCase
+}
+
+// NewTaxHandler создает новый экземпляр TaxHandler
+func NewTaxHandler(taxUseCase *usecase.TaxUseCase) *TaxHandler {
+	return &TaxHandler{taxUseCase: taxUseCase}
+}
+
+// CreateTaxRate обрабатывает запрос на добавление налоговой ставки
+func (h *TaxHandler) CreateTaxRate(c echo.Context) error {
+	var rate entities.TaxRate
+
+	if err := c.Bind(&rate); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	rate, err := h.taxUseCase.CreateRate(rate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, rate)
+}
+
+// DeleteTaxRate обрабатывает запрос на удаление налоговой ставки
+func (h *TaxHandler) DeleteTaxRate(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid tax rate ID"})
+	}
+	if err := h.taxUseCase.DeleteRate(id); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetTaxRates обрабатывает запрос на получение налоговых ставок
+func (h *TaxHandler) GetTaxRates(c echo.Context) error {
+	rates, err := h.taxUseCase.GetRates()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+)
+
+// TwoFactorHandler обрабатывает HTTP-запросы для настройки двухфакторной аутентификации
+type TwoFactorHandler struct {
+	twoFactorUseCase *usecase.TwoFactorUseCase
+	validator        *validator.Validate
+}
+
+// NewTwoFactorHandler создает новый экземпляр TwoFactorHandler
+func NewTwoFactorHandler(twoFactorUseCase *usecase.TwoFactorUseCase, validate *validator.Validate) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorUseCase: twoFactorUseCase, validator: validate}
+}
+
+// Setup обрабатывает запрос на создание секрета TOTP
+func (h *TwoFactorHandler) Setup(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	setup, err := h.twoFactorUseCase.Setup(userID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, setup)
+}
+
+// Enable обрабатывает запрос на включение двухфакторной аутентификации
+func (h *TwoFactorHandler) Enable(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var request entities.TwoFactorCode
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(request); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	codes, err := h.twoFactorUseCase.Enable(userID, request.Code)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, codes)
+}
+
+// Disable обрабатывает запрос на отключение двухфакторной аутентификации
+func (h *TwoFactorHandler) Disable(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var request entities.TwoFactorCode
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(request); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.twoFactorUseCase.Disable(userID, request.Code); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes обрабатывает запрос на выпуск новых резервных кодов
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var request entities.TwoFactorCode
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(request); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	codes, err := h.twoFactorUseCase.RegenerateRecoveryCodes(userID, request.Code)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, codes)
+}
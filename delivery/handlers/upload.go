@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+)
+
+var errFileTooLarge = errors.New("file is too large")
+
+// multipartOverhead запас на заголовки частей и остальные поля multipart-формы
+const multipartOverhead = 1 << 20
+
+// readFormFile читает файл из multipart-поля, ограничивая его размер maxSize байтами.
+// Тело запроса ограничивается до разбора формы с запасом на остальные поля.
+func readFormFile(c echo.Context, field string, maxSize int64) ([]byte, error) {
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, maxSize+multipartOverhead)
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, errFileTooLarge
+		}
+		return nil, errors.New(field + " file is required")
+	}
+	if fileHeader.Size > maxSize {
+		return nil, errFileTooLarge
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errFileTooLarge
+	}
+	return data, nil
+}
+
+// uploadErrorStatus возвращает HTTP-статус для ошибки чтения загруженного файла
+func uploadErrorStatus(err error) int {
+	if errors.Is(err, errFileTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"marketplace/internal/domain/entities"
 	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"math"
 	"net/http"
 	"strconv"
 )
@@ -52,15 +55,36 @@ func (h *UserHandler) Login(c echo.Context) error {
 	}
 
 	// Вызов метода Login и получение токенов
-	tokens, err := h.userUseCase.Login(credentials.Email, credentials.Password, c)
+	tokens, challenge, err := h.userUseCase.Login(credentials.Email, credentials.Password, c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+		return loginError(c, err)
+	}
+	if challenge != nil {
+		return c.JSON(http.StatusOK, challenge)
 	}
 
 	// Возвращаем токены
 	return c.JSON(http.StatusOK, tokens.CleanOutput())
 }
 
+// CompleteMFALogin обрабатывает второй шаг входа с кодом двухфакторной аутентификации
+func (h *UserHandler) CompleteMFALogin(c echo.Context) error {
+	var login entities.MFALogin
+	if err := c.Bind(&login); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(login); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	tokens, err := h.userUseCase.CompleteMFALogin(login.MFAToken, login.Code, c)
+	if err != nil {
+		return loginError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens.CleanOutput())
+}
+
 // GetUserByID обрабатывает запрос на получение информации о пользователе по ID
 func (h *UserHandler) GetUserByID(c echo.Context) error {
 	id := c.Param("id")
@@ -90,3 +114,103 @@ func (h *UserHandler) UpdateToken(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, user)
 }
+
+// RequestEmailVerification обрабатывает запрос на повторную отправку ссылки подтверждения email
+func (h *UserHandler) RequestEmailVerification(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.userUseCase.RequestEmailVerification(userID); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// VerifyEmail обрабатывает подтверждение email по токену из письма
+func (h *UserHandler) VerifyEmail(c echo.Context) error {
+	var verification entities.EmailVerification
+	if err := c.Bind(&verification); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(verification); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.userUseCase.VerifyEmail(verification.Token); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RequestPasswordReset обрабатывает запрос ссылки для сброса пароля. Ответ не зависит
+// от того, зарегистрирован ли email.
+func (h *UserHandler) RequestPasswordReset(c echo.Context) error {
+	var request entities.PasswordResetRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(request); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.userUseCase.RequestPasswordReset(request.Email); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// ResetPassword обрабатывает установку нового пароля по токену из письма
+func (h *UserHandler) ResetPassword(c echo.Context) error {
+	var reset entities.PasswordReset
+	if err := c.Bind(&reset); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(reset); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.userUseCase.ResetPassword(reset.Token, reset.Password); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UnlockAccount обрабатывает снятие блокировки входа по токену из письма
+func (h *UserHandler) UnlockAccount(c echo.Context) error {
+	var unlock entities.AccountUnlock
+	if err := c.Bind(&unlock); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	if err := h.validator.Struct(unlock); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.userUseCase.UnlockAccount(unlock.Token); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// loginError отвечает на неудачный вход. Если вход временно запрещен, возвращается 429
+// при задержке или 423 при блокировке учетной записи с заголовком Retry-After.
+func loginError(c echo.Context, err error) error {
+	var blocked *usecase.LoginBlockedError
+	if !errors.As(err, &blocked) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	retryAfter := int(math.Ceil(blocked.RetryAfter.Seconds()))
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	status := http.StatusTooManyRequests
+	if blocked.Locked {
+		status = http.StatusLocked
+	}
+	return c.JSON(status, echo.Map{"error": err.Error(), "retry_after": retryAfter})
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// WishlistHandler обрабатывает HTTP-запросы для списков желаний
+type WishlistHandler struct {
+	wishlistUseCase *usecase.WishlistUseCase
+}
+
+// NewWishlistHandler создает новый экземпляр WishlistHandler
+func NewWishlistHandler(wishlistUseCase *usecase.WishlistUseCase) *WishlistHandler {
+	return &WishlistHandler{wishlistUseCase: wishlistUseCase}
+}
+
+// CreateWishlist обрабатывает запрос на создание списка желаний
+func (h *WishlistHandler) CreateWishlist(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var wishlist entities.Wishlist
+	if err := c.Bind(&wishlist); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	wishlist, err = h.wishlistUseCase.CreateWishlist(userID, wishlist)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, wishlist)
+}
+
+// GetWishlists обрабатывает запрос на получение списков желаний пользователя
+func (h *WishlistHandler) GetWishlists(c echo.Context) error {
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	wishlists, err := h.wishlistUseCase.GetWishlists(userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, wishlists)
+}
+
+// GetWishlist обрабатывает запрос на получение своего или публичного списка желаний
+func (h *WishlistHandler) GetWishlist(c echo.Context) error {
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	wishlist, err := h.wishlistUseCase.GetWishlist(userID, wishlistID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// GetSharedWishlist обрабатывает запрос на получение списка желаний по ссылке
+func (h *WishlistHandler) GetSharedWishlist(c echo.Context) error {
+	wishlist, err := h.wishlistUseCase.GetSharedWishlist(c.Param("token"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// UpdateWishlist обрабатывает запрос на изменение названия и видимости списка желаний
+func (h *WishlistHandler) UpdateWishlist(c echo.Context) error {
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var wishlist entities.Wishlist
+	if err := c.Bind(&wishlist); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	wishlist.ID = wishlistID
+
+	wishlist, err = h.wishlistUseCase.UpdateWishlist(userID, wishlist)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// DeleteWishlist обрабатывает запрос на удаление списка желаний
+func (h *WishlistHandler) DeleteWishlist(c echo.Context) error {
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.wishlistUseCase.DeleteWishlist(userID, wishlistID); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AddWishlistItem обрабатывает запрос на добавление продукта в список желаний
+func (h *WishlistHandler) AddWishlistItem(c echo.Context) error {
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	var item entities.WishlistItem
+	if err := c.Bind(&item); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	wishlist, err := h.wishlistUseCase.AddItem(userID, wishlistID, item)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// RemoveWishlistItem обрабатывает запрос на удаление продукта из списка желаний;
+// вариант продукта указывается параметром sku
+func (h *WishlistHandler) RemoveWishlistItem(c echo.Context) error {
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	productID, err := strconv.ParseUint(c.Param("product_id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	userID, err := utils.UserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+
+	wishlist, err := h.wishlistUseCase.RemoveItem(userID, wishlistID, productID, c.QueryParam("sku"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"net/http"
+)
+
+// AccessPolicy требования к учетным записям администраторов и продавцов
+type AccessPolicy struct {
+	RequireVerifiedSellers bool // Действия продавца доступны только с подтвержденным email
+	RequireSellerTwoFactor bool // Действия продавца доступны только с включенной 2FA
+	RequireAdminTwoFactor  bool // Действия администратора доступны только с включенной 2FA
+}
+
+// AccessMiddleware проверяет права пользователя, прошедшего JWTMiddleware
+type AccessMiddleware struct {
+	userRepo  repository.UserRepository
+	tokenRepo repository.JWTRepository
+	policy    AccessPolicy
+}
+
+// NewAccessMiddleware создает новый экземпляр AccessMiddleware
+func NewAccessMiddleware(userRepo repository.UserRepository, tokenRepo repository.JWTRepository, policy AccessPolicy) *AccessMiddleware {
+	return &AccessMiddleware{userRepo: userRepo, tokenRepo: tokenRepo, policy: policy}
+}
+
+// ActiveSession отклоняет токены, выпущенные до отзыва всех сессий пользователя,
+// например до сброса пароля
+func (m *AccessMiddleware) ActiveSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := utils.UserIDFromContext(c)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+		}
+
+		revokedAt, err := m.tokenRepo.SessionsRevokedAt(userID)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+		}
+		// Метки времени в токенах с точностью до секунды, поэтому токен, выпущенный
+		// в ту же секунду, что и отзыв, тоже считается отозванным
+		if !revokedAt.IsZero() && !utils.TokenIssuedAtFromContext(c).After(revokedAt) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Session has been revoked"})
+		}
+
+		return next(c)
+	}
+}
+
+// SellerAccess пропускает к действиям продавца только пользователей, выполняющих
+// требования AccessPolicy: подтвержденный email и включенную 2FA
+func (m *AccessMiddleware) SellerAccess(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !m.policy.RequireVerifiedSellers && !m.policy.RequireSellerTwoFactor {
+			return next(c)
+		}
+
+		userID, err := utils.UserIDFromContext(c)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+		}
+
+		user, err := m.userRepo.FindByID(userID)
+		if err != nil {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+		}
+		if m.policy.RequireVerifiedSellers && !user.EmailVerified {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "Email verification required"})
+		}
+		if m.policy.RequireSellerTwoFactor && !user.TwoFactorEnabled {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "Two-factor authentication required"})
+		}
+
+		return next(c)
+	}
+}
+
+// AdminOnly пропускает только администраторов маркетплейса с подтвержденным email
+func (m *AccessMiddleware) AdminOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := utils.UserIDFromContext(c)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+		}
+
+		user, err := m.userRepo.FindByID(userID)
+		if err != nil || !user.IsAdmin || !user.EmailVerified {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "Admin access required"})
+		}
+		if m.policy.RequireAdminTwoFactor && !user.TwoFactorEnabled {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "Two-factor authentication required"})
+		}
+
+		return next(c)
+	}
+}
@@ -41,8 +41,21 @@ func JWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// Устанавливаем user_id в контекст для последующего использования в контроллерах
 		userID := claims["user_id"]
 		c.Set("user_id", userID)
+		c.Set("issued_at", claims["iat"])
 
 		// Переходим к следующему обработчику
 		return next(c)
 	}
 }
+
+// QueryTokenJWTMiddleware принимает токен также из параметра access_token, так как браузеры
+// не позволяют задать заголовки для WebSocket и EventSource. Проверку выполняет JWTMiddleware.
+func QueryTokenJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	authenticate := JWTMiddleware(next)
+	return func(c echo.Context) error {
+		if token := c.QueryParam("access_token"); token != "" && c.Request().Header.Get("Authorization") == "" {
+			c.Request().Header.Set("Authorization", "Bearer "+token)
+		}
+		return authenticate(c)
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// BodyLimit ограничивает тело запроса maxSize байтами. Запрос с большим Content-Length
+// отклоняется с 413 сразу, а чтение тела без заявленной длины обрывается на пределе.
+func BodyLimit(maxSize int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.ContentLength > maxSize {
+				return c.JSON(http.StatusRequestEntityTooLarge, echo.Map{"error": "Request body is too large"})
+			}
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, maxSize)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"io"
+	"marketplace/delivery/wrappers"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"net/http"
+	"strings"
+)
+
+// IdempotencyKeyHeader заголовок, в котором клиент передает ключ идемпотентности
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader отмечает ответ, возвращенный из сохраненного
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// IdempotencyMiddleware позволяет безопасно повторять POST-запросы с заголовком
+// Idempotency-Key: первый ответ сохраняется и возвращается на повторы с тем же ключом
+type IdempotencyMiddleware struct {
+	idempotencyRepo repository.IdempotencyRepository
+}
+
+// NewIdempotencyMiddleware создает новый экземпляр IdempotencyMiddleware
+func NewIdempotencyMiddleware(idempotencyRepo repository.IdempotencyRepository) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{idempotencyRepo: idempotencyRepo}
+}
+
+// Idempotent обрабатывает POST-запросы с ключом идемпотентности. Ключи действуют в
+// пределах пользователя, поэтому middleware должен стоять после JWTMiddleware.
+// Повтор с тем же ключом, но другим запросом отклоняется с 422, а повтор, пришедший
+// до завершения первого запроса, — с 409. Ответы 5xx не сохраняются, такой запрос
+// можно повторить с тем же ключом.
+func (m *IdempotencyMiddleware) Idempotent(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(IdempotencyKeyHeader)
+		if c.Request().Method != http.MethodPost || key == "" {
+			return next(c)
+		}
+		if len(key) > constants.MaxIdempotencyKeyLength {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Idempotency key is too long"})
+		}
+		userID, err := utils.UserIDFromContext(c)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+		}
+
+		requestHash, err := hashRequest(c)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return c.JSON(http.StatusRequestEntityTooLarge, echo.Map{"error": "Request body is too large"})
+			}
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+		}
+
+		stored, err := m.idempotencyRepo.Get(userID, key)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+		}
+		if stored != nil {
+			return replayResponse(c, *stored, requestHash)
+		}
+
+		acquired, heldHash, err := m.idempotencyRepo.Lock(userID, key, requestHash)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+		}
+		if !acquired {
+			if heldHash != "" && heldHash != requestHash {
+				return c.JSON(http.StatusUnprocessableEntity, echo.Map{"error": "Idempotency key was used with a different request"})
+			}
+			c.Response().Header().Set(echo.HeaderRetryAfter, "1")
+			return c.JSON(http.StatusConflict, echo.Map{"error": "A request with this idempotency key is in progress"})
+		}
+
+		// Первый запрос мог завершиться между проверкой и захватом ключа
+		if stored, err = m.idempotencyRepo.Get(userID, key); err != nil || stored != nil {
+			m.unlock(userID, key)
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+			}
+			return replayResponse(c, *stored, requestHash)
+		}
+
+		response := c.Response()
+		recorder := wrappers.NewResponseWriterWrapper(response.Writer)
+		response.Writer = &recorder
+		err = next(c)
+		response.Writer = recorder.Unwrap()
+
+		if err != nil || !response.Committed || recorder.StatusCode() >= http.StatusInternalServerError {
+			m.unlock(userID, key)
+			return err
+		}
+		if err = m.idempotencyRepo.Save(userID, key, entities.IdempotentResponse{
+			RequestHash: requestHash,
+			StatusCode:  recorder.StatusCode(),
+			Header:      replayableHeader(response.Header()),
+			Body:        recorder.Body(),
+		}); err != nil {
+			logrus.Errorf("Failed to save idempotent response: %v", err)
+			m.unlock(userID, key)
+		}
+		return nil
+	}
+}
+
+// unlock освобождает ключ, записывая ошибку хранилища в журнал. Ключ освободится
+// и сам через IdempotencyLockTimeout.
+func (m *IdempotencyMiddleware) unlock(userID uint64, key string) {
+	if err := m.idempotencyRepo.Unlock(userID, key); err != nil {
+		logrus.Errorf("Failed to unlock idempotency key: %v", err)
+	}
+}
+
+// hashRequest возвращает хэш метода, пути и тела запроса, оставляя тело доступным обработчику.
+// Тело больше MaxRequestBodySize не читается целиком в память.
+func hashRequest(c echo.Context) (string, error) {
+	req := c.Request()
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, constants.MaxRequestBodySize))
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// replayResponse возвращает сохраненный ответ, если он получен на тот же запрос
+func replayResponse(c echo.Context, stored entities.IdempotentResponse, requestHash string) error {
+	if stored.RequestHash != requestHash {
+		return c.JSON(http.StatusUnprocessableEntity, echo.Map{"error": "Idempotency key was used with a different request"})
+	}
+
+	header := c.Response().Header()
+	for name, values := range stored.Header {
+		header[name] = values
+	}
+	header.Set(IdempotentReplayedHeader, "true")
+	c.Response().WriteHeader(stored.StatusCode)
+	_, err := c.Response().Write(stored.Body)
+	return err
+}
+
+// replayableHeader возвращает заголовки ответа, которые имеет смысл повторять.
+// Заголовки RateLimit-* относятся к текущему запросу и выставляются заново.
+func replayableHeader(header http.Header) http.Header {
+	replayable := make(http.Header, len(header))
+	for name, values := range header {
+		if strings.HasPrefix(name, "Ratelimit-") {
+			continue
+		}
+		replayable[name] = append([]string(nil), values...)
+	}
+	return replayable
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitPolicy лимит запросов к группе маршрутов
+type RateLimitPolicy struct {
+	Name   string        // Имя политики, у каждой политики свои счетчики
+	Limit  int           // Сколько запросов разрешено за окно
+	Window time.Duration // Длина скользящего окна
+}
+
+// RateLimitMiddleware ограничивает частоту запросов. Запросы аутентифицированного
+// пользователя считаются по его ID, остальные — по IP-адресу.
+type RateLimitMiddleware struct {
+	limiter repository.RateLimiter
+}
+
+// NewRateLimitMiddleware создает новый экземпляр RateLimitMiddleware
+func NewRateLimitMiddleware(limiter repository.RateLimiter) *RateLimitMiddleware {
+	return &RateLimitMiddleware{limiter: limiter}
+}
+
+// Limit возвращает middleware, применяющий политику policy. Для учета по пользователю
+// он должен стоять после JWTMiddleware. Ответ содержит заголовки RateLimit-*, а при
+// превышении лимита — 429 с Retry-After. Политика с нулевым лимитом ничего не ограничивает.
+func (m *RateLimitMiddleware) Limit(policy RateLimitPolicy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if policy.Limit <= 0 {
+			return next
+		}
+		return func(c echo.Context) error {
+			client := "ip:" + c.RealIP()
+			if userID, err := utils.UserIDFromContext(c); err == nil {
+				client = fmt.Sprintf("user:%d", userID)
+			}
+
+			result, err := m.limiter.Allow(policy.Name+":"+client, policy.Limit, policy.Window)
+			if err != nil {
+				// Без счетчиков запрос лучше пропустить, чем отказать всем клиентам
+				logrus.Errorf("Failed to check rate limit: %v", err)
+				return next(c)
+			}
+
+			reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+			header := c.Response().Header()
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Window.Seconds())))
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", reset)
+			if !result.Allowed {
+				header.Set(echo.HeaderRetryAfter, reset)
+				return c.JSON(http.StatusTooManyRequests, echo.Map{"error": "Too many requests"})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -45,6 +45,22 @@ func (rww *ResponseWriterWrapper) WriteHeader(statusCode int) {
 	rww.w.WriteHeader(statusCode) // Вызываем WriteHeader на http.ResponseWriter
 }
 
+// Unwrap возвращает исходный http.ResponseWriter, чтобы http.ResponseController
+// мог выполнить Flush и Hijack для SSE и WebSocket
+func (rww *ResponseWriterWrapper) Unwrap() http.ResponseWriter {
+	return rww.w
+}
+
+// StatusCode возвращает записанный статус ответа
+func (rww *ResponseWriterWrapper) StatusCode() int {
+	return rww.statusCode
+}
+
+// Body возвращает записанное тело ответа
+func (rww *ResponseWriterWrapper) Body() []byte {
+	return rww.body.Bytes()
+}
+
 func (rww *ResponseWriterWrapper) String() string {
 	var buf bytes.Buffer
 	buf.WriteString("\nResponse: \n")
@@ -0,0 +1,215 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// keyPrefix общий префикс ключей потоков и каналов событий в Redis
+const keyPrefix = "events:"
+
+// subscriberBuffer сколько событий может ждать отправки медленному подписчику
+const subscriberBuffer = 64
+
+// redisEventBus - реализация EventBus на Redis: история топика хранится в потоке
+// ограниченной длины, а новые события рассылаются экземплярам сервера через pub/sub
+type redisEventBus struct {
+	redisClient *redis.Client
+	context     context.Context
+
+	mu          sync.Mutex
+	pubsub      *redis.PubSub
+	closed      bool
+	subscribers map[string]map[chan entities.Event]struct{}
+}
+
+// NewRedisEventBus - конструктор для создания нового экземпляра redisEventBus
+func NewRedisEventBus(redisClient *redis.Client) repository.EventBus {
+	return &redisEventBus{
+		redisClient: redisClient,
+		context:     context.Background(),
+		subscribers: make(map[string]map[chan entities.Event]struct{}),
+	}
+}
+
+// Publish сохраняет событие в поток топика и рассылает его всем экземплярам сервера
+func (b *redisEventBus) Publish(event entities.Event) (entities.Event, error) {
+	event.CreatedAt = time.Now()
+	id, err := b.redisClient.XAdd(b.context, &redis.XAddArgs{
+		Stream: keyPrefix + event.Topic,
+		MaxLen: constants.EventStreamMaxLength,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":       string(event.Type),
+			"payload":    string(event.Payload),
+			"created_at": event.CreatedAt.UnixNano(),
+		},
+	}).Result()
+	if err != nil {
+		return entities.Event{}, fmt.Errorf("failed to append event: %v", err)
+	}
+	event.ID = id
+
+	message, err := json.Marshal(publishedEvent{Topic: event.Topic, Event: event})
+	if err != nil {
+		return entities.Event{}, fmt.Errorf("failed to marshal event: %v", err)
+	}
+	if err = b.redisClient.Publish(b.context, keyPrefix+event.Topic, message).Err(); err != nil {
+		return entities.Event{}, fmt.Errorf("failed to publish event: %v", err)
+	}
+	return event, nil
+}
+
+// Replay возвращает события топика, опубликованные после afterID
+func (b *redisEventBus) Replay(topic, afterID string) ([]entities.Event, error) {
+	messages, err := b.redisClient.XRangeN(b.context, keyPrefix+topic, "("+afterID, "+", constants.EventStreamMaxLength).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %v", err)
+	}
+
+	events := make([]entities.Event, 0, len(messages))
+	for _, message := range messages {
+		events = append(events, streamEvent(topic, message))
+	}
+	return events, nil
+}
+
+// Subscribe подписывает на новые события топика до отмены ctx. Канал закрывается
+// при отмене или если подписчик не успевает забирать события; клиент
+// в этом случае переподключается и получает пропущенное через Replay.
+func (b *redisEventBus) Subscribe(ctx context.Context, topic string) (<-chan entities.Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, fmt.Errorf("event bus is closed")
+	}
+	if b.pubsub == nil {
+		if err := b.listen(); err != nil {
+			return nil, err
+		}
+	}
+
+	events := make(chan entities.Event, subscriberBuffer)
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[chan entities.Event]struct{})
+	}
+	b.subscribers[topic][events] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(topic, events)
+	}()
+	return events, nil
+}
+
+// publishedEvent событие вместе с топиком для передачи через pub/sub
+type publishedEvent struct {
+	Topic string         `json:"topic"`
+	Event entities.Event `json:"event"`
+}
+
+// listen подписывает экземпляр сервера на события всех топиков и раздает их локальным подписчикам
+func (b *redisEventBus) listen() error {
+	pubsub := b.redisClient.PSubscribe(b.context, keyPrefix+"*")
+	if _, err := pubsub.Receive(b.context); err != nil {
+		_ = pubsub.Close()
+		return fmt.Errorf("failed to subscribe to events: %v", err)
+	}
+	b.pubsub = pubsub
+
+	go func() {
+		for message := range pubsub.Channel() {
+			var published publishedEvent
+			if err := json.Unmarshal([]byte(message.Payload), &published); err != nil {
+				logrus.Errorf("Failed to decode event: %v", err)
+				continue
+			}
+			published.Event.Topic = published.Topic
+			b.dispatch(published.Event)
+		}
+	}()
+	return nil
+}
+
+// dispatch передает событие локальным подписчикам топика
+func (b *redisEventBus) dispatch(event entities.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for events := range b.subscribers[event.Topic] {
+		select {
+		case events <- event:
+		default:
+			// Подписчик отстал: отключаем его, пропущенное он получит через Replay
+			delete(b.subscribers[event.Topic], events)
+			close(events)
+		}
+	}
+}
+
+// unsubscribe удаляет подписчика и закрывает его канал, если он еще не закрыт
+func (b *redisEventBus) unsubscribe(topic string, events chan entities.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.subscribers[topic][events]; exists {
+		delete(b.subscribers[topic], events)
+		close(events)
+	}
+	if len(b.subscribers[topic]) == 0 {
+		delete(b.subscribers, topic)
+	}
+}
+
+// Close отключает экземпляр сервера от рассылки и закрывает каналы всех подписчиков,
+// чтобы открытые потоки событий завершились и клиенты переподключились к другому экземпляру
+func (b *redisEventBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for topic, subscribers := range b.subscribers {
+		for events := range subscribers {
+			close(events)
+		}
+		delete(b.subscribers, topic)
+	}
+	if b.pubsub == nil {
+		return nil
+	}
+	if err := b.pubsub.Close(); err != nil {
+		return fmt.Errorf("failed to unsubscribe from events: %v", err)
+	}
+	return nil
+}
+
+// streamEvent восстанавливает событие из записи потока Redis
+func streamEvent(topic string, message redis.XMessage) entities.Event {
+	event := entities.Event{ID: message.ID, Topic: topic}
+	if value, ok := message.Values["type"].(string); ok {
+		event.Type = enums.EventType(value)
+	}
+	if value, ok := message.Values["payload"].(string); ok {
+		event.Payload = json.RawMessage(value)
+	}
+	if value, ok := message.Values["created_at"].(string); ok {
+		if nanos, err := strconv.ParseInt(value, 10, 64); err == nil {
+			event.CreatedAt = time.Unix(0, nanos)
+		}
+	}
+	return event
+}
@@ -0,0 +1,113 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+)
+
+// pdfRenderer - реализация InvoiceRenderer, формирующая PDF. Шрифты Go встроены
+// в бинарный файл и содержат кириллицу, поэтому внешние файлы шрифтов не нужны.
+type pdfRenderer struct{}
+
+// NewPDFRenderer - конструктор PDF-формы счета
+func NewPDFRenderer() repository.InvoiceRenderer {
+	return pdfRenderer{}
+}
+
+func (pdfRenderer) ContentType() string {
+	return "application/pdf"
+}
+
+// Render формирует PDF. Документ зависит только от данных счета, включая дату
+// создания, поэтому повторная генерация дает тот же файл.
+func (pdfRenderer) Render(invoice entities.Invoice) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes("go", "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes("go", "B", gobold.TTF)
+	pdf.SetCreationDate(invoice.IssuedAt)
+	pdf.SetModificationDate(invoice.IssuedAt)
+	pdf.SetTitle("Счет "+invoice.Number, true)
+	pdf.AddPage()
+
+	pdf.SetFont("go", "B", 16)
+	pdf.CellFormat(0, 10, fmt.Sprintf("Счет № %s от %s", invoice.Number, invoice.IssuedAt.Format("02.01.2006")), "", 1, "L", false, 0, "")
+
+	pdf.SetFont("go", "", 10)
+	seller := invoice.Seller.StoreName
+	if invoice.Seller.LegalName != "" {
+		seller = invoice.Seller.LegalName
+	}
+	pdf.CellFormat(0, 6, "Продавец: "+seller, "", 1, "L", false, 0, "")
+	if invoice.Seller.TaxID != "" {
+		pdf.CellFormat(0, 6, "ИНН: "+invoice.Seller.TaxID, "", 1, "L", false, 0, "")
+	}
+	if invoice.Seller.LegalAddress != "" {
+		pdf.MultiCell(0, 6, "Адрес: "+invoice.Seller.LegalAddress, "", "L", false)
+	}
+	pdf.CellFormat(0, 6, "Заказ: "+invoice.Reference, "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	widths := []float64{10, 80, 20, 25, 25, 30}
+	pdf.SetFont("go", "B", 10)
+	for i, title := range []string{"№", "Наименование", "Кол-во", "Цена", "Скидка", "Сумма"} {
+		pdf.CellFormat(widths[i], 7, title, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("go", "", 10)
+	for i, line := range invoice.Lines {
+		name := line.Name
+		if line.SKU != "" {
+			name += " (" + line.SKU + ")"
+		}
+		pdf.CellFormat(widths[0], 7, fmt.Sprint(i+1), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], 7, name, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 7, fmt.Sprint(line.Quantity), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 7, amount(line.UnitPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 7, amount(line.Discount), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], 7, amount(line.Total), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	for _, discount := range invoice.Discounts {
+		title := discount.Name
+		if discount.Code != "" {
+			title = "Промокод " + discount.Code
+		}
+		totalRow(pdf, "Скидка: "+title, "-"+amount(discount.Amount))
+	}
+	for _, taxLine := range invoice.TaxLines {
+		title := fmt.Sprintf("%s, позиция %d", taxLine.Name, taxLine.LineIndex+1)
+		if taxLine.Included {
+			title += " (включен в цену)"
+		}
+		totalRow(pdf, title, amount(taxLine.Amount))
+	}
+	totalRow(pdf, "Сумма без скидок", amount(invoice.Subtotal))
+	totalRow(pdf, "Скидки", amount(invoice.DiscountTotal))
+	totalRow(pdf, "Налоги", amount(invoice.TaxTotal))
+	pdf.SetFont("go", "B", 11)
+	totalRow(pdf, "Итого, "+invoice.Currency, amount(invoice.Total))
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, fmt.Errorf("failed to render invoice: %v", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// totalRow выводит строку итогов: подпись слева, сумма справа
+func totalRow(pdf *fpdf.Fpdf, title, value string) {
+	pdf.CellFormat(160, 6, title, "", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 6, value, "", 1, "R", false, 0, "")
+}
+
+// amount форматирует сумму с копейками
+func amount(value float64) string {
+	return fmt.Sprintf("%.2f", value)
+}
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileMailer - реализация Mailer для разработки: письма сохраняются в каталог файлами .eml
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer - конструктор для создания отправителя, складывающего письма в каталог dir
+func NewFileMailer(dir, from string) (repository.Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %v", err)
+	}
+	return &fileMailer{dir: dir, from: from}, nil
+}
+
+func (m *fileMailer) Send(message entities.EmailMessage) error {
+	data, err := buildMessage(m.from, message)
+	if err != nil {
+		return fmt.Errorf("failed to build message: %v", err)
+	}
+
+	// Имя начинается со времени, чтобы письма в каталоге шли по порядку
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000"), uuid.New().String())
+	if err = os.WriteFile(filepath.Join(m.dir, name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %v", err)
+	}
+	return nil
+}
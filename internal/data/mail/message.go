@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"marketplace/internal/domain/entities"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+)
+
+// buildMessage собирает письмо в формате MIME с текстовой и HTML-версиями
+func buildMessage(from string, message entities.EmailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	header := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary=%q\r\n\r\n",
+		from,
+		message.To,
+		mime.QEncoding.Encode("utf-8", message.Subject),
+		time.Now().Format(time.RFC1123Z),
+		body.Boundary(),
+	)
+
+	// Порядок частей важен: почтовые клиенты показывают последнюю поддерживаемую
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	} {
+		if part.content == "" {
+			continue
+		}
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err = encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err = encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+
+	return append([]byte(header), buf.Bytes()...), nil
+}
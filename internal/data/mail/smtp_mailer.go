@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"fmt"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"net"
+	"net/mail"
+	"net/smtp"
+)
+
+// SMTPOptions параметры подключения к SMTP-серверу
+type SMTPOptions struct {
+	Host     string
+	Port     string
+	Username string // Пустое имя отключает авторизацию, например для MailHog
+	Password string
+	From     string
+}
+
+// smtpMailer - реализация Mailer, отправляющая письма через SMTP-сервер
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer - конструктор для создания отправителя писем через SMTP
+func NewSMTPMailer(options SMTPOptions) (repository.Mailer, error) {
+	if options.Host == "" || options.Port == "" {
+		return nil, fmt.Errorf("smtp host and port are required")
+	}
+	if _, err := mail.ParseAddress(options.From); err != nil {
+		return nil, fmt.Errorf("invalid sender address: %v", err)
+	}
+
+	var auth smtp.Auth
+	if options.Username != "" {
+		auth = smtp.PlainAuth("", options.Username, options.Password, options.Host)
+	}
+	return &smtpMailer{
+		addr: net.JoinHostPort(options.Host, options.Port),
+		auth: auth,
+		from: options.From,
+	}, nil
+}
+
+func (m *smtpMailer) Send(message entities.EmailMessage) error {
+	data, err := buildMessage(m.from, message)
+	if err != nil {
+		return fmt.Errorf("failed to build message: %v", err)
+	}
+
+	// В конверте SMTP указывается только адрес, без имени отправителя
+	sender, _ := mail.ParseAddress(m.from)
+	if err = smtp.SendMail(m.addr, m.auth, sender.Address, []string{message.To}, data); err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	return nil
+}
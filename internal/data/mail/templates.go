@@ -0,0 +1,19 @@
+package mail
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Templates возвращает шаблоны писем, разложенные по каталогам языков:
+// <язык>/<шаблон>.txt задает блоки subject и text, <язык>/<шаблон>.html — HTML-версию
+func Templates() fs.FS {
+	sub, err := fs.Sub(templates, "templates")
+	if err != nil {
+		panic(err) // Каталог встроен при сборке и всегда существует
+	}
+	return sub
+}
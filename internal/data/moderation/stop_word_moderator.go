@@ -0,0 +1,35 @@
+package moderation
+
+import (
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"strings"
+)
+
+// stopWordModerator - реализация ContentModerator, отправляющая на ручную проверку
+// тексты со стоп-словами
+type stopWordModerator struct {
+	stopWords []string
+}
+
+// NewStopWordModerator - конструктор модератора по списку стоп-слов; пустой список публикует все тексты
+func NewStopWordModerator(stopWords []string) repository.ContentModerator {
+	normalized := make([]string, 0, len(stopWords))
+	for _, word := range stopWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return &stopWordModerator{stopWords: normalized}
+}
+
+// Moderate публикует текст, если в нем нет стоп-слов, иначе оставляет его на проверку
+func (m *stopWordModerator) Moderate(text string) (enums.ModerationStatus, error) {
+	text = strings.ToLower(text)
+	for _, word := range m.stopWords {
+		if strings.Contains(text, word) {
+			return enums.PendingContent, nil
+		}
+	}
+	return enums.PublishedContent, nil
+}
@@ -0,0 +1,58 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"sync"
+)
+
+// DeclinedToken токен, по которому песочница отклоняет списание, чтобы клиенты
+// могли проверить обработку отказа
+const DeclinedToken = "tok_declined"
+
+// sandboxProvider - реализация PaymentProvider для разработки: деньги не списываются,
+// а платежи запоминаются в памяти, чтобы возвраты проверялись так же, как у провайдера
+type sandboxProvider struct {
+	charges map[string]float64 // Невозвращенный остаток по идентификатору платежа
+	mu      sync.Mutex
+}
+
+// NewSandboxProvider - конструктор платежной песочницы
+func NewSandboxProvider() repository.PaymentProvider {
+	return &sandboxProvider{charges: make(map[string]float64)}
+}
+
+func (p *sandboxProvider) Charge(charge entities.PaymentCharge) (string, error) {
+	if charge.Token == DeclinedToken {
+		return "", errors.New("payment declined")
+	}
+	if charge.Amount <= 0 {
+		return "", fmt.Errorf("invalid payment amount %.2f", charge.Amount)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := "sandbox_" + uuid.New().String()
+	p.charges[id] = charge.Amount
+	return id, nil
+}
+
+func (p *sandboxProvider) Refund(providerPaymentID string, amount float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	remaining, exists := p.charges[providerPaymentID]
+	if !exists {
+		return fmt.Errorf("payment %s not found", providerPaymentID)
+	}
+	if amount <= 0 || amount > remaining {
+		return fmt.Errorf("invalid refund amount %.2f", amount)
+	}
+	p.charges[providerPaymentID] = utils.RoundPrice(remaining - amount)
+	return nil
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"sync/atomic"
+	"time"
+)
+
+// fallbackRateLimiter обращается к основному ограничителю, а при его ошибке считает
+// запросы резервным, чтобы недоступность Redis не снимала ограничения совсем. Пока
+// основной недоступен, к нему обращается не чаще раза в RateLimiterRetryInterval один запрос,
+// а остальные не ждут его таймаута.
+type fallbackRateLimiter struct {
+	primary  repository.RateLimiter
+	fallback repository.RateLimiter
+	degraded atomic.Bool  // Основной ограничитель недоступен, в журнал пишутся только переключения
+	retryAt  atomic.Int64 // Время в наносекундах Unix, после которого можно снова обратиться к основному
+}
+
+// NewFallbackRateLimiter - конструктор для создания нового экземпляра fallbackRateLimiter
+func NewFallbackRateLimiter(primary, fallback repository.RateLimiter) repository.RateLimiter {
+	return &fallbackRateLimiter{primary: primary, fallback: fallback}
+}
+
+// Allow учитывает запрос основным ограничителем или, если он недоступен, резервным
+func (l *fallbackRateLimiter) Allow(key string, limit int, window time.Duration) (entities.RateLimitResult, error) {
+	if l.degraded.Load() && !l.probe() {
+		return l.fallback.Allow(key, limit, window)
+	}
+
+	result, err := l.primary.Allow(key, limit, window)
+	if err == nil {
+		if l.degraded.CompareAndSwap(true, false) {
+			logrus.Info("Rate limiter recovered")
+		}
+		return result, nil
+	}
+	l.retryAt.Store(time.Now().Add(constants.RateLimiterRetryInterval).UnixNano())
+	if l.degraded.CompareAndSwap(false, true) {
+		logrus.Warnf("Rate limiter unavailable, counting requests in memory: %v", err)
+	}
+	return l.fallback.Allow(key, limit, window)
+}
+
+// probe разрешает обратиться к основному ограничителю после паузы только одному запросу
+func (l *fallbackRateLimiter) probe() bool {
+	retryAt := l.retryAt.Load()
+	now := time.Now()
+	if now.UnixNano() < retryAt {
+		return false
+	}
+	return l.retryAt.CompareAndSwap(retryAt, now.Add(constants.RateLimiterRetryInterval).UnixNano())
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+// sweepInterval как часто из памяти удаляются ключи без запросов в окне
+const sweepInterval = time.Minute
+
+// memoryWindow отметки времени запросов по ключу в порядке поступления
+type memoryWindow struct {
+	requests []time.Time
+	window   time.Duration
+}
+
+// memoryRateLimiter - реализация RateLimiter в памяти процесса. Лимиты считаются
+// отдельно на каждом экземпляре сервера.
+type memoryRateLimiter struct {
+	windows   map[string]*memoryWindow
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// NewMemoryRateLimiter - конструктор для создания нового экземпляра memoryRateLimiter
+func NewMemoryRateLimiter() repository.RateLimiter {
+	return &memoryRateLimiter{
+		windows:   make(map[string]*memoryWindow),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow учитывает запрос по ключу key, если за окно window было меньше limit запросов
+func (l *memoryRateLimiter) Allow(key string, limit int, window time.Duration) (entities.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok {
+		w = &memoryWindow{}
+		l.windows[key] = w
+	}
+	w.window = window
+	w.expire(now)
+
+	allowed := len(w.requests) < limit
+	if allowed {
+		w.requests = append(w.requests, now)
+	}
+
+	var reset time.Duration
+	if len(w.requests) > 0 {
+		reset = w.requests[0].Add(window).Sub(now)
+	}
+	return entities.RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: limit - len(w.requests),
+		Reset:     reset,
+	}, nil
+}
+
+// sweep периодически удаляет ключи, запросы которых вышли за окно, чтобы
+// разовые клиенты не накапливались в памяти
+func (l *memoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, w := range l.windows {
+		if w.expire(now); len(w.requests) == 0 {
+			delete(l.windows, key)
+		}
+	}
+}
+
+// expire отбрасывает запросы старше окна
+func (w *memoryWindow) expire(now time.Time) {
+	i := 0
+	for i < len(w.requests) && now.Sub(w.requests[i]) >= w.window {
+		i++
+	}
+	w.requests = w.requests[i:]
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"time"
+)
+
+// slidingWindowScript атомарно учитывает запрос в скользящем окне: отметки времени
+// запросов хранятся в отсортированном множестве, устаревшие удаляются перед подсчетом.
+// Возвращает признак разрешения, число запросов в окне и миллисекунды до освобождения места.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+
+local reset = 0
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+// redisRateLimiter - реализация RateLimiter на Redis, общая для всех экземпляров сервера
+type redisRateLimiter struct {
+	redisClient *redis.Client
+	context     context.Context
+}
+
+// NewRedisRateLimiter - конструктор для создания нового экземпляра redisRateLimiter
+func NewRedisRateLimiter(redisClient *redis.Client) repository.RateLimiter {
+	return &redisRateLimiter{
+		redisClient: redisClient,
+		context:     context.Background(),
+	}
+}
+
+// Allow учитывает запрос по ключу key, если за окно window было меньше limit запросов
+func (l *redisRateLimiter) Allow(key string, limit int, window time.Duration) (entities.RateLimitResult, error) {
+	values, err := slidingWindowScript.Run(
+		l.context,
+		l.redisClient,
+		[]string{"rate_limit:" + key},
+		time.Now().UnixMilli(),
+		window.Milliseconds(),
+		limit,
+		uuid.New().String(),
+	).Int64Slice()
+	if err != nil {
+		return entities.RateLimitResult{}, fmt.Errorf("failed to check rate limit: %v", err)
+	}
+
+	return entities.RateLimitResult{
+		Allowed:   values[0] == 1,
+		Limit:     limit,
+		Remaining: limit - int(values[1]),
+		Reset:     time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
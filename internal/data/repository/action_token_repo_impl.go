@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"strconv"
+)
+
+// redisActionTokenRepository - реализация ActionTokenRepository для Redis
+type redisActionTokenRepository struct {
+	redisClient *redis.Client
+	context     context.Context
+}
+
+// NewRedisActionTokenRepository - конструктор для создания нового экземпляра redisActionTokenRepository
+func NewRedisActionTokenRepository(redisClient *redis.Client) repository.ActionTokenRepository {
+	return &redisActionTokenRepository{
+		redisClient: redisClient,
+		context:     context.Background(),
+	}
+}
+
+// Save сохраняет идентификатор токена на время его действия
+func (r *redisActionTokenRepository) Save(purpose enums.ActionToken, tokenID string, userID uint64) error {
+	if err := r.redisClient.SetEX(
+		r.context,
+		fmt.Sprintf("action_token_%s:%s", purpose, tokenID),
+		userID,
+		purpose.Duration(),
+	).Err(); err != nil {
+		return fmt.Errorf("failed to save token into Redis: %v", err)
+	}
+	return nil
+}
+
+// Consume атомарно удаляет токен и возвращает ID его пользователя, поэтому
+// токен можно использовать только один раз
+func (r *redisActionTokenRepository) Consume(purpose enums.ActionToken, tokenID string) (uint64, error) {
+	value, err := r.redisClient.GetDel(r.context, fmt.Sprintf("action_token_%s:%s", purpose, tokenID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, errors.New("token is invalid or has already been used")
+	} else if err != nil {
+		return 0, fmt.Errorf("error retrieving token: %v", err)
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryAddressRepository struct {
+	addresses map[uint64]entities.Address
+	nextID    uint64
+	mu        sync.Mutex
+}
+
+func NewAddressRepository() repository2.AddressRepository {
+	return &inMemoryAddressRepository{
+		addresses: make(map[uint64]entities.Address),
+	}
+}
+
+func (r *inMemoryAddressRepository) Save(address entities.Address) (entities.Address, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	address.ID = r.nextID
+	address.CreatedAt = time.Now()
+	address.UpdatedAt = address.CreatedAt
+
+	r.addresses[address.ID] = address
+	return address, nil
+}
+
+func (r *inMemoryAddressRepository) FindByID(id uint64) (entities.Address, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	address, exists := r.addresses[id]
+	if !exists {
+		return entities.Address{}, errors.New("address not found")
+	}
+
+	return address, nil
+}
+
+func (r *inMemoryAddressRepository) Update(address entities.Address) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.addresses[address.ID]
+	if !exists {
+		return errors.New("address not found")
+	}
+
+	// Обновляем время изменения, сохраняя время создания
+	address.CreatedAt = existing.CreatedAt
+	address.UpdatedAt = time.Now()
+
+	r.addresses[address.ID] = address
+	return nil
+}
+
+func (r *inMemoryAddressRepository) Delete(id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.addresses[id]
+	if !exists {
+		return errors.New("address not found")
+	}
+
+	delete(r.addresses, id)
+	return nil
+}
+
+func (r *inMemoryAddressRepository) FindAllByUser(userID uint64) ([]entities.Address, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var addresses []entities.Address
+	for _, address := range r.addresses {
+		if address.UserID == userID {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i].ID < addresses[j].ID })
+
+	return addresses, nil
+}
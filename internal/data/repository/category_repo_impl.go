@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+type inMemoryCategoryRepository struct {
+	categories map[uint64]entities.Category
+	mu         sync.Mutex
+}
+
+func NewCategoryRepository() repository2.CategoryRepository {
+	return &inMemoryCategoryRepository{
+		categories: make(map[uint64]entities.Category),
+	}
+}
+
+func (r *inMemoryCategoryRepository) Save(category entities.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Если категория уже существует, вернем ошибку
+	if _, exists := r.categories[category.ID]; exists {
+		return errors.New("category already exists")
+	}
+	if r.slugUsed(category.Slug, category.ID) {
+		return fmt.Errorf("slug %s is already used", category.Slug)
+	}
+
+	// Устанавливаем время создания и обновления
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = category.CreatedAt
+
+	r.categories[category.ID] = category
+	return nil
+}
+
+func (r *inMemoryCategoryRepository) FindByID(id uint64) (entities.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	category, exists := r.categories[id]
+	if !exists {
+		return entities.Category{}, errors.New("category not found")
+	}
+
+	return category, nil
+}
+
+func (r *inMemoryCategoryRepository) FindBySlug(slug string) (entities.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, category := range r.categories {
+		if category.Slug == slug {
+			return category, nil
+		}
+	}
+
+	return entities.Category{}, errors.New("category not found")
+}
+
+func (r *inMemoryCategoryRepository) Update(category entities.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.categories[category.ID]
+	if !exists {
+		return errors.New("category not found")
+	}
+	if r.slugUsed(category.Slug, category.ID) {
+		return fmt.Errorf("slug %s is already used", category.Slug)
+	}
+
+	// Обновляем время изменения, сохраняя время создания
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now()
+
+	r.categories[category.ID] = category
+	return nil
+}
+
+func (r *inMemoryCategoryRepository) Delete(id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.categories[id]
+	if !exists {
+		return errors.New("category not found")
+	}
+
+	delete(r.categories, id)
+	return nil
+}
+
+func (r *inMemoryCategoryRepository) FindAll() ([]entities.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var categories []entities.Category
+	for _, category := range r.categories {
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// slugUsed проверяет, занят ли slug другой категорией; вызывается под r.mu, чтобы
+// параллельные записи не сохранили две категории с одним slug
+func (r *inMemoryCategoryRepository) slugUsed(slug string, id uint64) bool {
+	for _, category := range r.categories {
+		if category.Slug == slug && category.ID != id {
+			return true
+		}
+	}
+	return false
+}
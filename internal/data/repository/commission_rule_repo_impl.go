@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryCommissionRuleRepository struct {
+	rules  map[uint64]entities.CommissionRule
+	nextID uint64
+	mu     sync.Mutex
+}
+
+func NewCommissionRuleRepository() repository2.CommissionRuleRepository {
+	return &inMemoryCommissionRuleRepository{
+		rules: make(map[uint64]entities.CommissionRule),
+	}
+}
+
+func (r *inMemoryCommissionRuleRepository) Save(rule entities.CommissionRule) (entities.CommissionRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Для магазина или категории может быть только одно правило
+	for _, existing := range r.rules {
+		if existing.StoreID == rule.StoreID && existing.CategoryID == rule.CategoryID {
+			return entities.CommissionRule{}, errors.New("commission rule already exists")
+		}
+	}
+
+	r.nextID++
+	rule.ID = r.nextID
+	rule.CreatedAt = time.Now()
+
+	r.rules[rule.ID] = rule
+	return rule, nil
+}
+
+func (r *inMemoryCommissionRuleRepository) Delete(id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.rules[id]
+	if !exists {
+		return errors.New("commission rule not found")
+	}
+
+	delete(r.rules, id)
+	return nil
+}
+
+func (r *inMemoryCommissionRuleRepository) FindAll() ([]entities.CommissionRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rules []entities.CommissionRule
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	return rules, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryConversationRepository struct {
+	conversations map[uint64]entities.Conversation
+	messages      map[uint64][]entities.Message // ID переписки -> сообщения по порядку
+	nextID        uint64
+	nextMessageID uint64
+	mu            sync.Mutex
+}
+
+func NewConversationRepository() repository2.ConversationRepository {
+	return &inMemoryConversationRepository{
+		conversations: make(map[uint64]entities.Conversation),
+		messages:      make(map[uint64][]entities.Message),
+	}
+}
+
+func (r *inMemoryConversationRepository) Save(conversation entities.Conversation) (entities.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	conversation.ID = r.nextID
+	conversation.CreatedAt = time.Now()
+	conversation.LastMessageAt = conversation.CreatedAt
+
+	r.conversations[conversation.ID] = conversation
+	return conversation, nil
+}
+
+func (r *inMemoryConversationRepository) FindByID(id uint64) (entities.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, exists := r.conversations[id]
+	if !exists {
+		return entities.Conversation{}, errors.New("conversation not found")
+	}
+
+	return conversation, nil
+}
+
+func (r *inMemoryConversationRepository) Find(storeID, buyerID, productID uint64) (entities.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, conversation := range r.conversations {
+		if conversation.StoreID == storeID && conversation.BuyerID == buyerID && conversation.ProductID == productID {
+			return conversation, nil
+		}
+	}
+	return entities.Conversation{}, errors.New("conversation not found")
+}
+
+func (r *inMemoryConversationRepository) FindAllByParticipant(userID uint64, storeIDs []uint64) ([]entities.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversations := []entities.Conversation{}
+	for _, conversation := range r.conversations {
+		if conversation.BuyerID == userID || slices.Contains(storeIDs, conversation.StoreID) {
+			conversations = append(conversations, conversation)
+		}
+	}
+	sort.Slice(conversations, func(i, j int) bool { return conversations[i].ID < conversations[j].ID })
+
+	return conversations, nil
+}
+
+func (r *inMemoryConversationRepository) SaveMessage(message entities.Message) (entities.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, exists := r.conversations[message.ConversationID]
+	if !exists {
+		return entities.Message{}, errors.New("conversation not found")
+	}
+
+	r.nextMessageID++
+	message.ID = r.nextMessageID
+	message.CreatedAt = time.Now()
+	message.ReadAt = nil
+	message.Attachments = append([]entities.MessageAttachment{}, message.Attachments...)
+
+	r.messages[conversation.ID] = append(r.messages[conversation.ID], message)
+	conversation.LastMessageAt = message.CreatedAt
+	r.conversations[conversation.ID] = conversation
+	return message, nil
+}
+
+func (r *inMemoryConversationRepository) FindMessages(conversationID uint64) ([]entities.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	messages := make([]entities.Message, 0, len(r.messages[conversationID]))
+	for _, message := range r.messages[conversationID] {
+		message.Attachments = append([]entities.MessageAttachment{}, message.Attachments...)
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (r *inMemoryConversationRepository) MarkRead(conversationID, readerID uint64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.conversations[conversationID]; !exists {
+		return errors.New("conversation not found")
+	}
+
+	// Прочитанными отмечаются только сообщения собеседника
+	messages := r.messages[conversationID]
+	for i := range messages {
+		if messages[i].SenderID != readerID && messages[i].ReadAt == nil {
+			readAt := at
+			messages[i].ReadAt = &readAt
+		}
+	}
+	return nil
+}
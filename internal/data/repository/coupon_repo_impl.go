@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+type inMemoryCouponRepository struct {
+	coupons     map[uint64]entities.Coupon
+	redemptions []entities.CouponRedemption
+	nextID      uint64
+	mu          sync.Mutex
+}
+
+func NewCouponRepository() repository2.CouponRepository {
+	return &inMemoryCouponRepository{
+		coupons: make(map[uint64]entities.Coupon),
+	}
+}
+
+func (r *inMemoryCouponRepository) Save(coupon entities.Coupon) (entities.Coupon, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Код промокода уникален во всем маркетплейсе
+	for _, existing := range r.coupons {
+		if existing.Code == coupon.Code {
+			return entities.Coupon{}, errors.New("coupon code already exists")
+		}
+	}
+
+	r.nextID++
+	coupon.ID = r.nextID
+	coupon.CreatedAt = time.Now()
+
+	r.coupons[coupon.ID] = coupon
+	return coupon, nil
+}
+
+func (r *inMemoryCouponRepository) FindByID(id uint64) (entities.Coupon, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	coupon, exists := r.coupons[id]
+	if !exists {
+		return entities.Coupon{}, errors.New("coupon not found")
+	}
+
+	return coupon, nil
+}
+
+func (r *inMemoryCouponRepository) FindByCode(code string) (entities.Coupon, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, coupon := range r.coupons {
+		if coupon.Code == code {
+			return coupon, nil
+		}
+	}
+
+	return entities.Coupon{}, errors.New("coupon not found")
+}
+
+func (r *inMemoryCouponRepository) Delete(id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.coupons[id]
+	if !exists {
+		return errors.New("coupon not found")
+	}
+
+	delete(r.coupons, id)
+	return nil
+}
+
+func (r *inMemoryCouponRepository) FindAllByStore(storeID uint64) ([]entities.Coupon, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var coupons []entities.Coupon
+	for _, coupon := range r.coupons {
+		if coupon.StoreID == storeID {
+			coupons = append(coupons, coupon)
+		}
+	}
+
+	return coupons, nil
+}
+
+func (r *inMemoryCouponRepository) SaveRedemptions(redemptions []entities.CouponRedemption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Лимиты проверяются под той же блокировкой, что и запись: параллельные заказы
+	// не могут превысить их. Сохраняются либо все использования, либо ни одного.
+	for _, redemption := range redemptions {
+		coupon, exists := r.coupons[redemption.CouponID]
+		if !exists {
+			return errors.New("coupon not found")
+		}
+
+		used, usedByUser := 0, 0
+		for _, existing := range r.redemptions {
+			if existing.CouponID == coupon.ID {
+				used++
+				if existing.UserID == redemption.UserID {
+					usedByUser++
+				}
+			}
+		}
+		if coupon.UsageLimit > 0 && used >= coupon.UsageLimit {
+			return fmt.Errorf("coupon %s usage limit reached", coupon.Code)
+		}
+		if coupon.UsageLimitPerUser > 0 && usedByUser >= coupon.UsageLimitPerUser {
+			return fmt.Errorf("coupon %s was already used", coupon.Code)
+		}
+	}
+
+	r.redemptions = append(r.redemptions, redemptions...)
+	return nil
+}
+
+func (r *inMemoryCouponRepository) CountRedemptions(couponID uint64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, redemption := range r.redemptions {
+		if redemption.CouponID == couponID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *inMemoryCouponRepository) CountUserRedemptions(couponID, userID uint64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, redemption := range r.redemptions {
+		if redemption.CouponID == couponID && redemption.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
+}
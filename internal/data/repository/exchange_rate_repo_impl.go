@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryExchangeRateRepository struct {
+	// Курсы по валютам, отсортированные по дате начала действия
+	rates map[string][]entities.ExchangeRate
+	mu    sync.Mutex
+}
+
+func NewExchangeRateRepository() repository2.ExchangeRateRepository {
+	return &inMemoryExchangeRateRepository{
+		rates: make(map[string][]entities.ExchangeRate),
+	}
+}
+
+func (r *inMemoryExchangeRateRepository) Save(rate entities.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history := r.rates[rate.Currency]
+	// Курс с той же датой начала действия заменяет предыдущий
+	for i, existing := range history {
+		if existing.EffectiveFrom.Equal(rate.EffectiveFrom) {
+			history[i] = rate
+			return nil
+		}
+	}
+
+	history = append(history, rate)
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].EffectiveFrom.Before(history[j].EffectiveFrom)
+	})
+	r.rates[rate.Currency] = history
+	return nil
+}
+
+func (r *inMemoryExchangeRateRepository) FindEffective(currency string, at time.Time) (entities.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history := r.rates[currency]
+	for i := len(history) - 1; i >= 0; i-- {
+		if !history[i].EffectiveFrom.After(at) {
+			return history[i], nil
+		}
+	}
+
+	return entities.ExchangeRate{}, errors.New("exchange rate not found")
+}
+
+func (r *inMemoryExchangeRateRepository) FindAll() ([]entities.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rates []entities.ExchangeRate
+	for _, history := range r.rates {
+		rates = append(rates, history...)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+)
+
+// redisIdempotencyRepository - реализация IdempotencyRepository для Redis
+type redisIdempotencyRepository struct {
+	redisClient *redis.Client
+	context     context.Context
+}
+
+// NewRedisIdempotencyRepository - конструктор для создания нового экземпляра redisIdempotencyRepository
+func NewRedisIdempotencyRepository(redisClient *redis.Client) repository.IdempotencyRepository {
+	return &redisIdempotencyRepository{
+		redisClient: redisClient,
+		context:     context.Background(),
+	}
+}
+
+// Get возвращает сохраненный ответ или nil, если запрос с этим ключом еще не выполнялся
+func (r *redisIdempotencyRepository) Get(userID uint64, key string) (*entities.IdempotentResponse, error) {
+	value, err := r.redisClient.Get(r.context, fmt.Sprintf("idempotency:%d:%s", userID, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error retrieving idempotent response: %v", err)
+	}
+
+	var response entities.IdempotentResponse
+	if err = json.Unmarshal(value, &response); err != nil {
+		return nil, fmt.Errorf("invalid idempotent response: %v", err)
+	}
+	return &response, nil
+}
+
+// Lock захватывает ключ на время выполнения запроса. Если ключ уже захвачен,
+// возвращает false и хэш запроса, который его удерживает.
+func (r *redisIdempotencyRepository) Lock(userID uint64, key, requestHash string) (bool, string, error) {
+	lockKey := fmt.Sprintf("idempotency_lock:%d:%s", userID, key)
+	acquired, err := r.redisClient.SetNX(r.context, lockKey, requestHash, constants.IdempotencyLockTimeout).Result()
+	if err != nil {
+		return false, "", fmt.Errorf("failed to lock idempotency key: %v", err)
+	}
+	if acquired {
+		return true, "", nil
+	}
+
+	heldHash, err := r.redisClient.Get(r.context, lockKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, "", fmt.Errorf("error retrieving idempotency lock: %v", err)
+	}
+	return false, heldHash, nil
+}
+
+// Save сохраняет ответ на IdempotencyKeyLifetime и освобождает ключ
+func (r *redisIdempotencyRepository) Save(userID uint64, key string, response entities.IdempotentResponse) error {
+	value, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if _, err = r.redisClient.TxPipelined(r.context, func(pipe redis.Pipeliner) error {
+		pipe.SetEX(r.context, fmt.Sprintf("idempotency:%d:%s", userID, key), value, constants.IdempotencyKeyLifetime)
+		pipe.Del(r.context, fmt.Sprintf("idempotency_lock:%d:%s", userID, key))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to save idempotent response: %v", err)
+	}
+	return nil
+}
+
+// Unlock освобождает ключ без сохранения ответа, чтобы запрос можно было повторить
+func (r *redisIdempotencyRepository) Unlock(userID uint64, key string) error {
+	if err := r.redisClient.Del(r.context, fmt.Sprintf("idempotency_lock:%d:%s", userID, key)).Err(); err != nil {
+		return fmt.Errorf("failed to unlock idempotency key: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryInvoiceRepository struct {
+	invoices    map[uint64]entities.Invoice
+	nextID      uint64
+	nextNumbers map[uint64]uint64 // Последний номер счета по магазинам
+	mu          sync.Mutex
+}
+
+func NewInvoiceRepository() repository2.InvoiceRepository {
+	return &inMemoryInvoiceRepository{
+		invoices:    make(map[uint64]entities.Invoice),
+		nextNumbers: make(map[uint64]uint64),
+	}
+}
+
+func (r *inMemoryInvoiceRepository) Save(invoice entities.Invoice) (entities.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// На один заказ магазин выставляет один счет
+	for _, existing := range r.invoices {
+		if existing.StoreID == invoice.StoreID && existing.Reference == invoice.Reference {
+			return entities.Invoice{}, errors.New("invoice already issued")
+		}
+	}
+
+	// Номера идут подряд без пропусков в пределах магазина
+	r.nextNumbers[invoice.StoreID]++
+	invoice.Number = fmt.Sprintf("%d-%06d", invoice.StoreID, r.nextNumbers[invoice.StoreID])
+	r.nextID++
+	invoice.ID = r.nextID
+	invoice.IssuedAt = time.Now()
+
+	r.invoices[invoice.ID] = invoice
+	return invoice, nil
+}
+
+func (r *inMemoryInvoiceRepository) FindByID(id uint64) (entities.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invoice, exists := r.invoices[id]
+	if !exists {
+		return entities.Invoice{}, errors.New("invoice not found")
+	}
+
+	return invoice, nil
+}
+
+func (r *inMemoryInvoiceRepository) FindByReference(storeID uint64, reference string) (entities.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, invoice := range r.invoices {
+		if invoice.StoreID == storeID && invoice.Reference == reference {
+			return invoice, nil
+		}
+	}
+
+	return entities.Invoice{}, errors.New("invoice not found")
+}
+
+func (r *inMemoryInvoiceRepository) FindAllByStore(storeID uint64) ([]entities.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var invoices []entities.Invoice
+	for _, invoice := range r.invoices {
+		if invoice.StoreID == storeID {
+			invoices = append(invoices, invoice)
+		}
+	}
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].ID < invoices[j].ID })
+
+	return invoices, nil
+}
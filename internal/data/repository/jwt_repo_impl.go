@@ -10,6 +10,9 @@ import (
 	"marketplace/internal/domain/entities"
 	"marketplace/internal/domain/enums"
 	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"strconv"
+	"time"
 )
 
 // redisJWTRepository - реализация JWTRepository для Redis
@@ -95,3 +98,33 @@ func (r *redisJWTRepository) DeleteToken(userID uint64, tokenType enums.Token, c
 	}
 	return nil
 }
+
+// RevokeSessions отзывает все выданные пользователю токены. Метка хранится, пока
+// не истечет самый долгоживущий из токенов, выпущенных до нее.
+func (r *redisJWTRepository) RevokeSessions(userID uint64) error {
+	if err := r.redisClient.SetEX(
+		r.context,
+		fmt.Sprintf("sessions_revoked_at:%d", userID),
+		time.Now().Unix(),
+		constants.RefreshTokenLifetime,
+	).Err(); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %v", err)
+	}
+	return nil
+}
+
+// SessionsRevokedAt возвращает время последнего отзыва сессий пользователя или нулевое время
+func (r *redisJWTRepository) SessionsRevokedAt(userID uint64) (time.Time, error) {
+	value, err := r.redisClient.Get(r.context, fmt.Sprintf("sessions_revoked_at:%d", userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("error retrieving session revocation: %v", err)
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid session revocation time: %v", err)
+	}
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+type inMemoryLedgerRepository struct {
+	transactions []entities.LedgerTransaction
+	batches      []entities.PayoutBatch
+	mu           sync.Mutex
+}
+
+func NewLedgerRepository() repository2.LedgerRepository {
+	return &inMemoryLedgerRepository{}
+}
+
+func (r *inMemoryLedgerRepository) Append(transaction entities.LedgerTransaction) (entities.LedgerTransaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction.ID = uint64(len(r.transactions) + 1)
+	transaction.CreatedAt = time.Now()
+	// Копируем проводки, чтобы запись нельзя было изменить через исходный срез
+	transaction.Postings = append([]entities.LedgerPosting{}, transaction.Postings...)
+
+	r.transactions = append(r.transactions, transaction)
+	return transaction, nil
+}
+
+func (r *inMemoryLedgerRepository) FindAll() ([]entities.LedgerTransaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return copyTransactions(r.transactions, func(entities.LedgerTransaction) bool { return true }), nil
+}
+
+func (r *inMemoryLedgerRepository) FindByStore(storeID uint64) ([]entities.LedgerTransaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return copyTransactions(r.transactions, func(transaction entities.LedgerTransaction) bool {
+		return transaction.StoreID == storeID
+	}), nil
+}
+
+func (r *inMemoryLedgerRepository) FindByReference(reference string) ([]entities.LedgerTransaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return copyTransactions(r.transactions, func(transaction entities.LedgerTransaction) bool {
+		return transaction.Reference == reference
+	}), nil
+}
+
+func (r *inMemoryLedgerRepository) SaveBatch(batch entities.PayoutBatch) (entities.PayoutBatch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch.ID = uint64(len(r.batches) + 1)
+	batch.CreatedAt = time.Now()
+	batch.Payouts = append([]entities.Payout{}, batch.Payouts...)
+
+	r.batches = append(r.batches, batch)
+	return batch, nil
+}
+
+func (r *inMemoryLedgerRepository) FindBatches() ([]entities.PayoutBatch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batches := make([]entities.PayoutBatch, 0, len(r.batches))
+	for _, batch := range r.batches {
+		batch.Payouts = append([]entities.Payout{}, batch.Payouts...)
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+func copyTransactions(
+	transactions []entities.LedgerTransaction,
+	match func(entities.LedgerTransaction) bool,
+) []entities.LedgerTransaction {
+	result := []entities.LedgerTransaction{}
+	for _, transaction := range transactions {
+		if !match(transaction) {
+			continue
+		}
+		transaction.Postings = append([]entities.LedgerPosting{}, transaction.Postings...)
+		result = append(result, transaction)
+	}
+	return result
+}
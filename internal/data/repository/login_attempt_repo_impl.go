@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"time"
+)
+
+// lockedBlock значение ключа блокировки, отличающее блокировку учетной записи от задержки
+const lockedBlock = "locked"
+
+// redisLoginAttemptRepository - реализация LoginAttemptRepository для Redis
+type redisLoginAttemptRepository struct {
+	redisClient *redis.Client
+	context     context.Context
+}
+
+// NewRedisLoginAttemptRepository - конструктор для создания нового экземпляра redisLoginAttemptRepository
+func NewRedisLoginAttemptRepository(redisClient *redis.Client) repository.LoginAttemptRepository {
+	return &redisLoginAttemptRepository{
+		redisClient: redisClient,
+		context:     context.Background(),
+	}
+}
+
+// RegisterFailure увеличивает счетчик неудачных попыток и возвращает его новое значение.
+// Счетчик забывается, если в течение LoginFailureWindow не было новых неудач.
+func (r *redisLoginAttemptRepository) RegisterFailure(scope enums.LoginAttemptScope, key string) (int64, error) {
+	counterKey := fmt.Sprintf("login_failures_%s:%s", scope, key)
+
+	var incr *redis.IntCmd
+	if _, err := r.redisClient.TxPipelined(r.context, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(r.context, counterKey)
+		pipe.Expire(r.context, counterKey, constants.LoginFailureWindow)
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("failed to register login failure: %v", err)
+	}
+	return incr.Val(), nil
+}
+
+// Block запрещает попытки входа на duration. locked отличает блокировку учетной записи от задержки.
+func (r *redisLoginAttemptRepository) Block(scope enums.LoginAttemptScope, key string, duration time.Duration, locked bool) error {
+	value := "backoff"
+	if locked {
+		value = lockedBlock
+	}
+	if err := r.redisClient.Set(r.context, fmt.Sprintf("login_block_%s:%s", scope, key), value, duration).Err(); err != nil {
+		return fmt.Errorf("failed to block login attempts: %v", err)
+	}
+	return nil
+}
+
+// BlockedFor возвращает, сколько еще действует запрет входа и является ли он блокировкой.
+// Нулевая длительность означает, что входить можно.
+func (r *redisLoginAttemptRepository) BlockedFor(scope enums.LoginAttemptScope, key string) (time.Duration, bool, error) {
+	blockKey := fmt.Sprintf("login_block_%s:%s", scope, key)
+
+	var get *redis.StringCmd
+	var ttl *redis.DurationCmd
+	if _, err := r.redisClient.Pipelined(r.context, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(r.context, blockKey)
+		ttl = pipe.PTTL(r.context, blockKey)
+		return nil
+	}); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, false, fmt.Errorf("error retrieving login block: %v", err)
+	}
+	if errors.Is(get.Err(), redis.Nil) || ttl.Val() <= 0 {
+		return 0, false, nil
+	}
+	return ttl.Val(), get.Val() == lockedBlock, nil
+}
+
+// Reset сбрасывает счетчик неудачных попыток и снимает запрет входа
+func (r *redisLoginAttemptRepository) Reset(scope enums.LoginAttemptScope, key string) error {
+	if err := r.redisClient.Del(
+		r.context,
+		fmt.Sprintf("login_failures_%s:%s", scope, key),
+		fmt.Sprintf("login_block_%s:%s", scope, key),
+	).Err(); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sync"
+)
+
+type inMemoryNotificationPreferencesRepository struct {
+	preferences map[uint64]entities.NotificationPreferences
+	mu          sync.Mutex
+}
+
+func NewNotificationPreferencesRepository() repository2.NotificationPreferencesRepository {
+	return &inMemoryNotificationPreferencesRepository{
+		preferences: make(map[uint64]entities.NotificationPreferences),
+	}
+}
+
+func (r *inMemoryNotificationPreferencesRepository) Save(preferences entities.NotificationPreferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.preferences[preferences.UserID] = preferences
+	return nil
+}
+
+func (r *inMemoryNotificationPreferencesRepository) FindByUser(userID uint64) (entities.NotificationPreferences, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	preferences, exists := r.preferences[userID]
+	if !exists {
+		return entities.NotificationPreferences{}, errors.New("notification preferences not found")
+	}
+
+	return preferences, nil
+}
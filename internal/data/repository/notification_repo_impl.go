@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryNotificationRepository struct {
+	notifications map[uint64]entities.Notification
+	nextID        uint64
+	mu            sync.Mutex
+}
+
+func NewNotificationRepository() repository2.NotificationRepository {
+	return &inMemoryNotificationRepository{
+		notifications: make(map[uint64]entities.Notification),
+	}
+}
+
+func (r *inMemoryNotificationRepository) Save(notification entities.Notification) (entities.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	notification.ID = r.nextID
+	notification.CreatedAt = time.Now()
+
+	r.notifications[notification.ID] = notification
+	return notification, nil
+}
+
+func (r *inMemoryNotificationRepository) FindByID(id uint64) (entities.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification, exists := r.notifications[id]
+	if !exists {
+		return entities.Notification{}, errors.New("notification not found")
+	}
+
+	return notification, nil
+}
+
+func (r *inMemoryNotificationRepository) Update(notification entities.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.notifications[notification.ID]
+	if !exists {
+		return errors.New("notification not found")
+	}
+
+	r.notifications[notification.ID] = notification
+	return nil
+}
+
+func (r *inMemoryNotificationRepository) FindAllByUser(userID uint64) ([]entities.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notifications := []entities.Notification{}
+	for _, notification := range r.notifications {
+		if notification.UserID == userID {
+			notifications = append(notifications, notification)
+		}
+	}
+	// Новые уведомления первыми
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].ID > notifications[j].ID })
+
+	return notifications, nil
+}
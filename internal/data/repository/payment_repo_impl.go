@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"sync"
+	"time"
+)
+
+type inMemoryPaymentRepository struct {
+	payments map[uint64]entities.Payment
+	nextID   uint64
+	mu       sync.Mutex
+}
+
+func NewPaymentRepository() repository2.PaymentRepository {
+	return &inMemoryPaymentRepository{
+		payments: make(map[uint64]entities.Payment),
+	}
+}
+
+func (r *inMemoryPaymentRepository) Save(payment entities.Payment) (entities.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.payments {
+		if existing.InvoiceID == payment.InvoiceID {
+			return entities.Payment{}, errors.New("invoice is already paid")
+		}
+	}
+
+	r.nextID++
+	payment.ID = r.nextID
+	payment.CreatedAt = time.Now()
+	r.payments[payment.ID] = payment
+	return payment, nil
+}
+
+func (r *inMemoryPaymentRepository) FindByInvoice(invoiceID uint64) (entities.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, payment := range r.payments {
+		if payment.InvoiceID == invoiceID {
+			return payment, nil
+		}
+	}
+	return entities.Payment{}, errors.New("payment not found")
+}
+
+func (r *inMemoryPaymentRepository) FindAllByBuyer(buyerID uint64) ([]entities.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var payments []entities.Payment
+	for _, payment := range r.payments {
+		if payment.BuyerID == buyerID {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, nil
+}
+
+func (r *inMemoryPaymentRepository) AddRefund(id uint64, amount float64) (entities.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payment, exists := r.payments[id]
+	if !exists {
+		return entities.Payment{}, errors.New("payment not found")
+	}
+	refunded := utils.RoundPrice(payment.Refunded + amount)
+	if refunded > payment.Amount {
+		return entities.Payment{}, fmt.Errorf("refund exceeds refundable amount %.2f", utils.RoundPrice(payment.Amount-payment.Refunded))
+	}
+	payment.Refunded = refunded
+	r.payments[id] = payment
+	return payment, nil
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"errors"
+	"fmt"
 	"marketplace/internal/domain/entities"
 	repository2 "marketplace/internal/domain/repository"
+	"slices"
 	"sync"
 	"time"
 )
@@ -52,11 +54,15 @@ func (r *inMemoryProductRepository) Update(product entities.Product) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	_, exists := r.products[product.ID]
+	existing, exists := r.products[product.ID]
 	if !exists {
 		return errors.New("product not found")
 	}
 
+	// Изображения и рейтинг изменяются только через отдельные методы
+	product.Images = existing.Images
+	product.Rating = existing.Rating
+	product.ReviewCount = existing.ReviewCount
 	// Обновляем время изменения
 	product.UpdatedAt = time.Now()
 
@@ -64,6 +70,89 @@ func (r *inMemoryProductRepository) Update(product entities.Product) error {
 	return nil
 }
 
+func (r *inMemoryProductRepository) UpdateRating(id uint64, rating float64, reviewCount int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, exists := r.products[id]
+	if !exists {
+		return errors.New("product not found")
+	}
+
+	product.Rating = rating
+	product.ReviewCount = reviewCount
+	r.products[id] = product
+	return nil
+}
+
+// AdjustStock изменяет остаток варианта продукта на delta
+func (r *inMemoryProductRepository) AdjustStock(id uint64, sku string, delta int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, exists := r.products[id]
+	if !exists {
+		return errors.New("product not found")
+	}
+
+	// Варианты копируются: прежний срез может быть у вызывающего кода
+	product.Variants = slices.Clone(product.Variants)
+	for i, variant := range product.Variants {
+		if variant.SKU != sku {
+			continue
+		}
+		if variant.Stock+delta < 0 {
+			return errors.New("insufficient stock")
+		}
+		product.Variants[i].Stock += delta
+		product.UpdatedAt = time.Now()
+		r.products[id] = product
+		return nil
+	}
+	return errors.New("variant not found")
+}
+
+// AppendImage добавляет изображение, если у продукта их меньше limit
+func (r *inMemoryProductRepository) AppendImage(id uint64, image entities.ProductImage, limit int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, exists := r.products[id]
+	if !exists {
+		return errors.New("product not found")
+	}
+	if len(product.Images) >= limit {
+		return fmt.Errorf("product already has %d images", limit)
+	}
+
+	product.Images = append(product.Images[:len(product.Images):len(product.Images)], image)
+	product.UpdatedAt = time.Now()
+	r.products[id] = product
+	return nil
+}
+
+// RemoveImage удаляет изображение из продукта и возвращает его
+func (r *inMemoryProductRepository) RemoveImage(id uint64, imageID string) (entities.ProductImage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, exists := r.products[id]
+	if !exists {
+		return entities.ProductImage{}, errors.New("product not found")
+	}
+
+	for i, image := range product.Images {
+		if image.ID != imageID {
+			continue
+		}
+		product.Images = append(product.Images[:i:i], product.Images[i+1:]...)
+		product.UpdatedAt = time.Now()
+		r.products[id] = product
+		return image, nil
+	}
+	return entities.ProductImage{}, errors.New("image not found")
+}
+
 func (r *inMemoryProductRepository) Delete(id uint64) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -90,3 +179,38 @@ func (r *inMemoryProductRepository) FindAllByStore(storeID uint64) ([]entities.P
 
 	return products, nil
 }
+
+func (r *inMemoryProductRepository) FindBySKU(sku string) (entities.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, product := range r.products {
+		if _, ok := product.FindVariant(sku); ok {
+			return product, nil
+		}
+	}
+
+	return entities.Product{}, errors.New("product not found")
+}
+
+func (r *inMemoryProductRepository) FindAllByCategories(categoryIDs []uint64) ([]entities.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[uint64]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		wanted[id] = true
+	}
+
+	var products []entities.Product
+	for _, product := range r.products {
+		for _, categoryID := range product.CategoryIDs {
+			if wanted[categoryID] {
+				products = append(products, product)
+				break
+			}
+		}
+	}
+
+	return products, nil
+}
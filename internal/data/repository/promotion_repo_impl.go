@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+type inMemoryPromotionRepository struct {
+	promotions map[uint64]entities.Promotion
+	nextID     uint64
+	mu         sync.Mutex
+}
+
+func NewPromotionRepository() repository2.PromotionRepository {
+	return &inMemoryPromotionRepository{
+		promotions: make(map[uint64]entities.Promotion),
+	}
+}
+
+func (r *inMemoryPromotionRepository) Save(promotion entities.Promotion) (entities.Promotion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	promotion.ID = r.nextID
+	promotion.CreatedAt = time.Now()
+
+	r.promotions[promotion.ID] = promotion
+	return promotion, nil
+}
+
+func (r *inMemoryPromotionRepository) FindByID(id uint64) (entities.Promotion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	promotion, exists := r.promotions[id]
+	if !exists {
+		return entities.Promotion{}, errors.New("promotion not found")
+	}
+
+	return promotion, nil
+}
+
+func (r *inMemoryPromotionRepository) Delete(id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.promotions[id]
+	if !exists {
+		return errors.New("promotion not found")
+	}
+
+	delete(r.promotions, id)
+	return nil
+}
+
+func (r *inMemoryPromotionRepository) FindAllByStore(storeID uint64) ([]entities.Promotion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var promotions []entities.Promotion
+	for _, promotion := range r.promotions {
+		if promotion.StoreID == storeID {
+			promotions = append(promotions, promotion)
+		}
+	}
+
+	return promotions, nil
+}
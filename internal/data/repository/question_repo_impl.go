@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type answerVote struct {
+	answerID uint64
+	userID   uint64
+}
+
+type inMemoryQuestionRepository struct {
+	questions      map[uint64]entities.Question
+	answers        map[uint64]entities.Answer
+	votes          map[answerVote]struct{}
+	nextQuestionID uint64
+	nextAnswerID   uint64
+	mu             sync.Mutex
+}
+
+func NewQuestionRepository() repository2.QuestionRepository {
+	return &inMemoryQuestionRepository{
+		questions: make(map[uint64]entities.Question),
+		answers:   make(map[uint64]entities.Answer),
+		votes:     make(map[answerVote]struct{}),
+	}
+}
+
+func (r *inMemoryQuestionRepository) SaveQuestion(question entities.Question) (entities.Question, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextQuestionID++
+	question.ID = r.nextQuestionID
+	question.CreatedAt = time.Now()
+	question.UpdatedAt = question.CreatedAt
+
+	r.questions[question.ID] = question
+	return question, nil
+}
+
+func (r *inMemoryQuestionRepository) FindQuestionByID(id uint64) (entities.Question, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	question, exists := r.questions[id]
+	if !exists {
+		return entities.Question{}, errors.New("question not found")
+	}
+
+	return question, nil
+}
+
+func (r *inMemoryQuestionRepository) UpdateQuestion(question entities.Question) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.questions[question.ID]
+	if !exists {
+		return errors.New("question not found")
+	}
+
+	question.CreatedAt = existing.CreatedAt
+	question.UpdatedAt = time.Now()
+
+	r.questions[question.ID] = question
+	return nil
+}
+
+func (r *inMemoryQuestionRepository) FindQuestionsByProduct(productID uint64) ([]entities.Question, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	questions := []entities.Question{}
+	for _, question := range r.questions {
+		if question.ProductID == productID {
+			questions = append(questions, question)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool { return questions[i].ID < questions[j].ID })
+
+	return questions, nil
+}
+
+func (r *inMemoryQuestionRepository) FindQuestionsByStatus(status enums.ModerationStatus) ([]entities.Question, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	questions := []entities.Question{}
+	for _, question := range r.questions {
+		if question.Status == status {
+			questions = append(questions, question)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool { return questions[i].ID < questions[j].ID })
+
+	return questions, nil
+}
+
+func (r *inMemoryQuestionRepository) SaveAnswer(answer entities.Answer) (entities.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.questions[answer.QuestionID]; !exists {
+		return entities.Answer{}, errors.New("question not found")
+	}
+
+	r.nextAnswerID++
+	answer.ID = r.nextAnswerID
+	answer.CreatedAt = time.Now()
+	answer.UpdatedAt = answer.CreatedAt
+
+	r.answers[answer.ID] = answer
+	return answer, nil
+}
+
+func (r *inMemoryQuestionRepository) FindAnswerByID(id uint64) (entities.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	answer, exists := r.answers[id]
+	if !exists {
+		return entities.Answer{}, errors.New("answer not found")
+	}
+
+	return answer, nil
+}
+
+func (r *inMemoryQuestionRepository) UpdateAnswer(answer entities.Answer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.answers[answer.ID]
+	if !exists {
+		return errors.New("answer not found")
+	}
+
+	answer.CreatedAt = existing.CreatedAt
+	answer.UpdatedAt = time.Now()
+
+	r.answers[answer.ID] = answer
+	return nil
+}
+
+func (r *inMemoryQuestionRepository) FindAnswersByQuestion(questionID uint64) ([]entities.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	answers := []entities.Answer{}
+	for _, answer := range r.answers {
+		if answer.QuestionID == questionID {
+			answers = append(answers, answer)
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool { return answers[i].ID < answers[j].ID })
+
+	return answers, nil
+}
+
+func (r *inMemoryQuestionRepository) FindAnswersByStatus(status enums.ModerationStatus) ([]entities.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	answers := []entities.Answer{}
+	for _, answer := range r.answers {
+		if answer.Status == status {
+			answers = append(answers, answer)
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool { return answers[i].ID < answers[j].ID })
+
+	return answers, nil
+}
+
+func (r *inMemoryQuestionRepository) AddVote(answerID, userID uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	vote := answerVote{answerID: answerID, userID: userID}
+	if _, exists := r.votes[vote]; exists {
+		return errors.New("answer already upvoted")
+	}
+
+	r.votes[vote] = struct{}{}
+	return nil
+}
+
+func (r *inMemoryQuestionRepository) RemoveVote(answerID, userID uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	vote := answerVote{answerID: answerID, userID: userID}
+	if _, exists := r.votes[vote]; !exists {
+		return errors.New("vote not found")
+	}
+
+	delete(r.votes, vote)
+	return nil
+}
+
+func (r *inMemoryQuestionRepository) CountVotes(answerID uint64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for vote := range r.votes {
+		if vote.answerID == answerID {
+			count++
+		}
+	}
+	return count, nil
+}
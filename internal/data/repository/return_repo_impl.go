@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryReturnRepository struct {
+	requests map[uint64]entities.ReturnRequest
+	nextID   uint64
+	mu       sync.Mutex
+}
+
+func NewReturnRepository() repository2.ReturnRepository {
+	return &inMemoryReturnRepository{
+		requests: make(map[uint64]entities.ReturnRequest),
+	}
+}
+
+// Save сохраняет заявку, если вместе с прежними неотклоненными заявками
+// на ту же позицию счета возвращается не больше maxQuantity единиц
+func (r *inMemoryReturnRepository) Save(request entities.ReturnRequest, maxQuantity int) (entities.ReturnRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	returned := 0
+	for _, existing := range r.requests {
+		if existing.InvoiceID == request.InvoiceID && existing.LineIndex == request.LineIndex && existing.Status != enums.ReturnRejected {
+			returned += existing.Quantity
+		}
+	}
+	if returned+request.Quantity > maxQuantity {
+		return entities.ReturnRequest{}, fmt.Errorf("only %d items can be returned", max(maxQuantity-returned, 0))
+	}
+
+	r.nextID++
+	request.ID = r.nextID
+	request.CreatedAt = time.Now()
+	request.UpdatedAt = request.CreatedAt
+
+	r.requests[request.ID] = request
+	return request, nil
+}
+
+func (r *inMemoryReturnRepository) FindByID(id uint64) (entities.ReturnRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, exists := r.requests[id]
+	if !exists {
+		return entities.ReturnRequest{}, errors.New("return request not found")
+	}
+
+	return request, nil
+}
+
+// Transition сохраняет заявку, только если ее текущее состояние все еще from,
+// поэтому один и тот же переход не выполняется дважды
+func (r *inMemoryReturnRepository) Transition(request entities.ReturnRequest, from enums.ReturnStatus) (entities.ReturnRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.requests[request.ID]
+	if !exists {
+		return entities.ReturnRequest{}, errors.New("return request not found")
+	}
+	if existing.Status != from {
+		return entities.ReturnRequest{}, fmt.Errorf("return request is %s", existing.Status)
+	}
+
+	// Фотографии добавляются только через AppendPhoto
+	request.Photos = existing.Photos
+	request.UpdatedAt = time.Now()
+	r.requests[request.ID] = request
+	return request, nil
+}
+
+// AppendPhoto добавляет фотографию к еще не рассмотренной заявке, если их меньше limit
+func (r *inMemoryReturnRepository) AppendPhoto(id uint64, photo entities.ReturnPhoto, limit int) (entities.ReturnRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, exists := r.requests[id]
+	if !exists {
+		return entities.ReturnRequest{}, errors.New("return request not found")
+	}
+	if request.Status != enums.ReturnRequested {
+		return entities.ReturnRequest{}, fmt.Errorf("return request is %s", request.Status)
+	}
+	if len(request.Photos) >= limit {
+		return entities.ReturnRequest{}, fmt.Errorf("return request already has %d photos", limit)
+	}
+
+	request.Photos = append(request.Photos[:len(request.Photos):len(request.Photos)], photo)
+	request.UpdatedAt = time.Now()
+	r.requests[id] = request
+	return request, nil
+}
+
+func (r *inMemoryReturnRepository) FindAllByStore(storeID uint64) ([]entities.ReturnRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var requests []entities.ReturnRequest
+	for _, request := range r.requests {
+		if request.StoreID == storeID {
+			requests = append(requests, request)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].ID < requests[j].ID })
+
+	return requests, nil
+}
+
+func (r *inMemoryReturnRepository) FindAllByBuyer(buyerID uint64) ([]entities.ReturnRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var requests []entities.ReturnRequest
+	for _, request := range r.requests {
+		if request.BuyerID == buyerID {
+			requests = append(requests, request)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].ID < requests[j].ID })
+
+	return requests, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+type inMemoryReviewRepository struct {
+	reviews map[uint64]entities.Review
+	nextID  uint64
+	mu      sync.Mutex
+}
+
+func NewReviewRepository() repository2.ReviewRepository {
+	return &inMemoryReviewRepository{
+		reviews: make(map[uint64]entities.Review),
+	}
+}
+
+func (r *inMemoryReviewRepository) Save(review entities.Review) (entities.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Один пользователь может оставить только один отзыв на продукт
+	for _, existing := range r.reviews {
+		if existing.UserID == review.UserID && existing.ProductID == review.ProductID {
+			return entities.Review{}, errors.New("review already exists")
+		}
+	}
+
+	r.nextID++
+	review.ID = r.nextID
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = review.CreatedAt
+
+	r.reviews[review.ID] = review
+	return review, nil
+}
+
+func (r *inMemoryReviewRepository) FindByID(id uint64) (entities.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	review, exists := r.reviews[id]
+	if !exists {
+		return entities.Review{}, errors.New("review not found")
+	}
+
+	return review, nil
+}
+
+func (r *inMemoryReviewRepository) Update(review entities.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.reviews[review.ID]
+	if !exists {
+		return errors.New("review not found")
+	}
+
+	// Обновляем время изменения
+	review.UpdatedAt = time.Now()
+
+	r.reviews[review.ID] = review
+	return nil
+}
+
+func (r *inMemoryReviewRepository) SetReply(id uint64, reply entities.ReviewReply) (entities.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	review, exists := r.reviews[id]
+	if !exists {
+		return entities.Review{}, errors.New("review not found")
+	}
+
+	review.Reply = &reply
+	review.UpdatedAt = time.Now()
+	r.reviews[id] = review
+	return review, nil
+}
+
+// AppendPhoto добавляет фотографию, если у отзыва их меньше limit
+func (r *inMemoryReviewRepository) AppendPhoto(id uint64, url string, limit int) (entities.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	review, exists := r.reviews[id]
+	if !exists {
+		return entities.Review{}, errors.New("review not found")
+	}
+	if len(review.Photos) >= limit {
+		return entities.Review{}, fmt.Errorf("review already has %d photos", limit)
+	}
+
+	review.Photos = append(review.Photos[:len(review.Photos):len(review.Photos)], url)
+	review.UpdatedAt = time.Now()
+	r.reviews[id] = review
+	return review, nil
+}
+
+func (r *inMemoryReviewRepository) FindByUserAndProduct(userID, productID uint64) (entities.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, review := range r.reviews {
+		if review.UserID == userID && review.ProductID == productID {
+			return review, nil
+		}
+	}
+
+	return entities.Review{}, errors.New("review not found")
+}
+
+func (r *inMemoryReviewRepository) FindAllByProduct(productID uint64) ([]entities.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reviews []entities.Review
+	for _, review := range r.reviews {
+		if review.ProductID == productID {
+			reviews = append(reviews, review)
+		}
+	}
+
+	return reviews, nil
+}
+
+func (r *inMemoryReviewRepository) FindAllByStore(storeID uint64) ([]entities.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reviews []entities.Review
+	for _, review := range r.reviews {
+		if review.StoreID == storeID {
+			reviews = append(reviews, review)
+		}
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+type inMemoryShippingProfileRepository struct {
+	profiles map[uint64]entities.ShippingProfile
+	mu       sync.Mutex
+}
+
+func NewShippingProfileRepository() repository2.ShippingProfileRepository {
+	return &inMemoryShippingProfileRepository{
+		profiles: make(map[uint64]entities.ShippingProfile),
+	}
+}
+
+// Save создает или заменяет профиль доставки магазина
+func (r *inMemoryShippingProfileRepository) Save(profile entities.ShippingProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	profile.UpdatedAt = time.Now()
+
+	r.profiles[profile.StoreID] = profile
+	return nil
+}
+
+func (r *inMemoryShippingProfileRepository) FindByStore(storeID uint64) (entities.ShippingProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	profile, exists := r.profiles[storeID]
+	if !exists {
+		return entities.ShippingProfile{}, errors.New("shipping profile not found")
+	}
+
+	return profile, nil
+}
@@ -52,11 +52,14 @@ func (r *inMemoryStoreRepository) Update(store entities.Store) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	_, exists := r.stores[store.ID]
+	existing, exists := r.stores[store.ID]
 	if !exists {
 		return errors.New("store not found")
 	}
 
+	// Рейтинг изменяется только через UpdateRating
+	store.Rating = existing.Rating
+	store.ReviewCount = existing.ReviewCount
 	// Обновляем время изменения
 	store.UpdatedAt = time.Now()
 
@@ -64,6 +67,21 @@ func (r *inMemoryStoreRepository) Update(store entities.Store) error {
 	return nil
 }
 
+func (r *inMemoryStoreRepository) UpdateRating(id uint64, rating float64, reviewCount int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	store, exists := r.stores[id]
+	if !exists {
+		return errors.New("store not found")
+	}
+
+	store.Rating = rating
+	store.ReviewCount = reviewCount
+	r.stores[id] = store
+	return nil
+}
+
 func (r *inMemoryStoreRepository) Delete(id uint64) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
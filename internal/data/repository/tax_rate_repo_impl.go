@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+)
+
+type inMemoryTaxRateRepository struct {
+	rates  map[uint64]entities.TaxRate
+	nextID uint64
+	mu     sync.Mutex
+}
+
+func NewTaxRateRepository() repository2.TaxRateRepository {
+	return &inMemoryTaxRateRepository{
+		rates: make(map[uint64]entities.TaxRate),
+	}
+}
+
+func (r *inMemoryTaxRateRepository) Save(rate entities.TaxRate) (entities.TaxRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Для региона и налогового класса может быть только одна ставка
+	for _, existing := range r.rates {
+		if existing.Country == rate.Country && existing.Region == rate.Region && existing.TaxClass == rate.TaxClass {
+			return entities.TaxRate{}, errors.New("tax rate already exists")
+		}
+	}
+
+	r.nextID++
+	rate.ID = r.nextID
+
+	r.rates[rate.ID] = rate
+	return rate, nil
+}
+
+func (r *inMemoryTaxRateRepository) Delete(id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.rates[id]
+	if !exists {
+		return errors.New("tax rate not found")
+	}
+
+	delete(r.rates, id)
+	return nil
+}
+
+func (r *inMemoryTaxRateRepository) FindAll() ([]entities.TaxRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rates []entities.TaxRate
+	for _, rate := range r.rates {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].ID < rates[j].ID })
+
+	return rates, nil
+}
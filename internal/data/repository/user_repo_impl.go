@@ -4,29 +4,48 @@ import (
 	"errors"
 	"marketplace/internal/domain/entities"
 	repository2 "marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"sync"
 )
 
+// userRepository хранит email в нижнем регистре, поэтому адреса, отличающиеся
+// только регистром, относятся к одному пользователю
 type userRepository struct {
 	// Можно использовать базу данных здесь, например, Gorm или другое хранилище
-	users map[string]entities.User
+	users  map[string]entities.User
+	emails map[uint64]string // Email пользователя по ID
+	nextID uint64
+	mu     sync.Mutex
 }
 
 func NewUserRepository() repository2.UserRepository {
 	return &userRepository{
-		users: make(map[string]entities.User),
+		users:  make(map[string]entities.User),
+		emails: make(map[uint64]string),
 	}
 }
 
-func (r *userRepository) Create(user entities.User) error {
+// Create сохраняет нового пользователя. ID назначается хранилищем, переданный игнорируется.
+func (r *userRepository) Create(user entities.User) (entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.Email = utils.NormalizeEmail(user.Email)
 	if _, exists := r.users[user.Email]; exists {
-		return errors.New("user already exists")
+		return entities.User{}, errors.New("user already exists")
 	}
+	r.nextID++
+	user.ID = r.nextID
 	r.users[user.Email] = user
-	return nil
+	r.emails[user.ID] = user.Email
+	return user, nil
 }
 
 func (r *userRepository) FindByEmail(email string) (entities.User, error) {
-	user, exists := r.users[email]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[utils.NormalizeEmail(email)]
 	if !exists {
 		return entities.User{}, errors.New("user not found")
 	}
@@ -34,10 +53,64 @@ func (r *userRepository) FindByEmail(email string) (entities.User, error) {
 }
 
 func (r *userRepository) FindByID(id uint64) (entities.User, error) {
-	for _, user := range r.users {
-		if user.ID == id {
-			return user, nil
-		}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[r.emails[id]]
+	if !exists {
+		return entities.User{}, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (r *userRepository) Update(user entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Пользователь определяется по ID, чтобы обновление не задело чужую запись с тем же email
+	user.Email = utils.NormalizeEmail(user.Email)
+	if email, exists := r.emails[user.ID]; !exists || email != user.Email {
+		return errors.New("user not found")
+	}
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *userRepository) SetPassword(id uint64, password string) error {
+	return r.modify(id, func(user *entities.User) {
+		user.Password = password
+	})
+}
+
+func (r *userRepository) SetEmailVerified(id uint64) error {
+	return r.modify(id, func(user *entities.User) {
+		user.EmailVerified = true
+	})
+}
+
+func (r *userRepository) SetAdmin(id uint64, isAdmin bool) error {
+	return r.modify(id, func(user *entities.User) {
+		user.IsAdmin = isAdmin
+	})
+}
+
+func (r *userRepository) SetTwoFactor(id uint64, state entities.TwoFactorState) error {
+	return r.modify(id, func(user *entities.User) {
+		user.TwoFactorState = state
+	})
+}
+
+// modify изменяет сохраненного пользователя под блокировкой хранилища
+func (r *userRepository) modify(id uint64, change func(user *entities.User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	email, exists := r.emails[id]
+	if !exists {
+		return errors.New("user not found")
 	}
-	return entities.User{}, errors.New("user not found")
+	user := r.users[email]
+	change(&user)
+	r.users[email] = user
+	return nil
 }
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"errors"
+	"marketplace/internal/domain/entities"
+	repository2 "marketplace/internal/domain/repository"
+	"sort"
+	"sync"
+	"time"
+)
+
+type inMemoryWishlistRepository struct {
+	wishlists map[uint64]entities.Wishlist
+	nextID    uint64
+	mu        sync.Mutex
+}
+
+func NewWishlistRepository() repository2.WishlistRepository {
+	return &inMemoryWishlistRepository{
+		wishlists: make(map[uint64]entities.Wishlist),
+	}
+}
+
+func (r *inMemoryWishlistRepository) Save(wishlist entities.Wishlist) (entities.Wishlist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	wishlist.ID = r.nextID
+	wishlist.CreatedAt = time.Now()
+	wishlist.UpdatedAt = wishlist.CreatedAt
+	wishlist.Items = append([]entities.WishlistItem{}, wishlist.Items...)
+
+	r.wishlists[wishlist.ID] = wishlist
+	return wishlist, nil
+}
+
+func (r *inMemoryWishlistRepository) FindByID(id uint64) (entities.Wishlist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wishlist, exists := r.wishlists[id]
+	if !exists {
+		return entities.Wishlist{}, errors.New("wishlist not found")
+	}
+
+	wishlist.Items = append([]entities.WishlistItem{}, wishlist.Items...)
+	return wishlist, nil
+}
+
+func (r *inMemoryWishlistRepository) FindByShareToken(token string) (entities.Wishlist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, wishlist := range r.wishlists {
+		if token != "" && wishlist.ShareToken == token {
+			wishlist.Items = append([]entities.WishlistItem{}, wishlist.Items...)
+			return wishlist, nil
+		}
+	}
+	return entities.Wishlist{}, errors.New("wishlist not found")
+}
+
+func (r *inMemoryWishlistRepository) Update(wishlist entities.Wishlist) (entities.Wishlist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.wishlists[wishlist.ID]
+	if !exists {
+		return entities.Wishlist{}, errors.New("wishlist not found")
+	}
+
+	// Обновляем время изменения, сохраняя время создания
+	wishlist.CreatedAt = existing.CreatedAt
+	wishlist.UpdatedAt = time.Now()
+	wishlist.Items = append([]entities.WishlistItem{}, wishlist.Items...)
+
+	r.wishlists[wishlist.ID] = wishlist
+	return wishlist, nil
+}
+
+func (r *inMemoryWishlistRepository) Delete(id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.wishlists[id]
+	if !exists {
+		return errors.New("wishlist not found")
+	}
+
+	delete(r.wishlists, id)
+	return nil
+}
+
+func (r *inMemoryWishlistRepository) FindAllByUser(userID uint64) ([]entities.Wishlist, error) {
+	return r.findAll(func(wishlist entities.Wishlist) bool { return wishlist.UserID == userID }), nil
+}
+
+func (r *inMemoryWishlistRepository) FindAllByProduct(productID uint64) ([]entities.Wishlist, error) {
+	return r.findAll(func(wishlist entities.Wishlist) bool {
+		for _, item := range wishlist.Items {
+			if item.ProductID == productID {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *inMemoryWishlistRepository) findAll(match func(entities.Wishlist) bool) []entities.Wishlist {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wishlists := []entities.Wishlist{}
+	for _, wishlist := range r.wishlists {
+		if match(wishlist) {
+			wishlist.Items = append([]entities.WishlistItem{}, wishlist.Items...)
+			wishlists = append(wishlists, wishlist)
+		}
+	}
+	sort.Slice(wishlists, func(i, j int) bool { return wishlists[i].ID < wishlists[j].ID })
+	return wishlists
+}
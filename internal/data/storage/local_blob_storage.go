@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"marketplace/internal/domain/repository"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localBlobStorage - реализация BlobStorage в локальной файловой системе
+type localBlobStorage struct {
+	baseDir   string
+	publicURL string
+}
+
+// NewLocalBlobStorage - конструктор для создания хранилища в каталоге baseDir,
+// файлы которого раздаются по адресу publicURL
+func NewLocalBlobStorage(baseDir, publicURL string) (repository.BlobStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %v", err)
+	}
+	return &localBlobStorage{
+		baseDir:   baseDir,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}, nil
+}
+
+func (s *localBlobStorage) Put(key string, data []byte, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+	if err = os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	return nil
+}
+
+func (s *localBlobStorage) Get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New("file not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	return data, nil
+}
+
+func (s *localBlobStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %v", err)
+	}
+	return nil
+}
+
+func (s *localBlobStorage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// path переводит ключ в путь внутри baseDir, не допуская выхода за его пределы
+func (s *localBlobStorage) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid storage key: %s", key)
+	}
+	return filepath.Join(s.baseDir, filepath.FromSlash(key)), nil
+}
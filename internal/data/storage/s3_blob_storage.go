@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
+	"marketplace/internal/domain/repository"
+	"strings"
+)
+
+// s3BlobStorage - реализация BlobStorage для S3-совместимых хранилищ (AWS S3, MinIO)
+type s3BlobStorage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+	context   context.Context
+}
+
+// S3Options параметры подключения к S3-совместимому хранилищу
+type S3Options struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	UseSSL    bool
+	PublicURL string // Адрес, по которому объекты бакета доступны клиентам
+}
+
+// NewS3BlobStorage - конструктор, создающий бакет при его отсутствии
+func NewS3BlobStorage(options S3Options) (repository.BlobStorage, error) {
+	client, err := minio.New(options.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(options.AccessKey, options.SecretKey, ""),
+		Secure: options.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %v", err)
+	}
+
+	ctx := context.Background()
+	exists, err := client.BucketExists(ctx, options.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to S3: %v", err)
+	}
+	if !exists {
+		if err = client.MakeBucket(ctx, options.Bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket: %v", err)
+		}
+	}
+
+	publicURL := options.PublicURL
+	if publicURL == "" {
+		scheme := "http"
+		if options.UseSSL {
+			scheme = "https"
+		}
+		publicURL = fmt.Sprintf("%s://%s/%s", scheme, options.Endpoint, options.Bucket)
+	}
+
+	return &s3BlobStorage{
+		client:    client,
+		bucket:    options.Bucket,
+		publicURL: strings.TrimRight(publicURL, "/"),
+		context:   ctx,
+	}, nil
+}
+
+func (s *s3BlobStorage) Put(key string, data []byte, contentType string) error {
+	_, err := s.client.PutObject(
+		s.context,
+		s.bucket,
+		key,
+		bytes.NewReader(data),
+		int64(len(data)),
+		minio.PutObjectOptions{ContentType: contentType},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %v", err)
+	}
+	return nil
+}
+
+func (s *s3BlobStorage) Get(key string) ([]byte, error) {
+	object, err := s.client.GetObject(s.context, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %v", err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("file not found")
+		}
+		return nil, fmt.Errorf("failed to read object: %v", err)
+	}
+	return data, nil
+}
+
+func (s *s3BlobStorage) Delete(key string) error {
+	if err := s.client.RemoveObject(s.context, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object: %v", err)
+	}
+	return nil
+}
+
+func (s *s3BlobStorage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
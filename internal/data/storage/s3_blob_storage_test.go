@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// Интеграционный тест S3-хранилища запускается только против реального сервера, например локального MinIO:
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//	S3_TEST_ENDPOINT=localhost:9000 go test ./internal/data/storage/
+//
+// Ключи по умолчанию совпадают с ключами MinIO (minioadmin), их можно задать через S3_TEST_ACCESS_KEY и S3_TEST_SECRET_KEY.
+func newTestS3BlobStorage(t *testing.T) (*s3BlobStorage, string) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+	accessKey, secretKey := os.Getenv("S3_TEST_ACCESS_KEY"), os.Getenv("S3_TEST_SECRET_KEY")
+	if accessKey == "" {
+		accessKey = "minioadmin"
+	}
+	if secretKey == "" {
+		secretKey = "minioadmin"
+	}
+
+	// Отдельный бакет на каждый запуск, чтобы тесты не видели чужих объектов
+	bucket := fmt.Sprintf("marketplace-test-%d", time.Now().UnixNano())
+	blobStorage, err := NewS3BlobStorage(S3Options{
+		Endpoint:  endpoint,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Bucket:    bucket,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3 := blobStorage.(*s3BlobStorage)
+	t.Cleanup(func() { _ = s3.client.RemoveBucket(s3.context, bucket) })
+	return s3, bucket
+}
+
+func TestS3BlobStorage(t *testing.T) {
+	s3, bucket := newTestS3BlobStorage(t)
+
+	key := "products/1/image/original.png"
+	data := []byte("\x89PNG\r\n\x1a\ntest")
+	if err := s3.Put(key, data, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s3.Get(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Get returned %q, want %q", got, data)
+	}
+
+	wantURL := fmt.Sprintf("http://%s/%s/%s", os.Getenv("S3_TEST_ENDPOINT"), bucket, key)
+	if url := s3.URL(key); url != wantURL {
+		t.Errorf("URL = %s, want %s", url, wantURL)
+	}
+
+	if err = s3.Delete(key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s3.Get(key); err == nil {
+		t.Error("Get returned a deleted object")
+	}
+}
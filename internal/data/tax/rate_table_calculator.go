@@ -0,0 +1,75 @@
+package tax
+
+import (
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"strings"
+)
+
+// rateTableCalculator - реализация TaxCalculator по таблице ставок TaxRateRepository
+type rateTableCalculator struct {
+	rateRepo repository.TaxRateRepository
+}
+
+// NewRateTableCalculator - конструктор встроенного калькулятора налогов
+func NewRateTableCalculator(rateRepo repository.TaxRateRepository) repository.TaxCalculator {
+	return &rateTableCalculator{rateRepo: rateRepo}
+}
+
+// Calculate подбирает для каждой позиции ставку ее налогового класса:
+// ставка региона важнее ставки страны. Позиции без подходящей ставки не облагаются.
+func (c *rateTableCalculator) Calculate(
+	destination entities.ShippingDestination,
+	lines []entities.TaxableLine,
+) ([]entities.TaxLine, error) {
+	rates, err := c.rateRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	taxLines := make([]entities.TaxLine, 0, len(lines))
+	for _, line := range lines {
+		rate, ok := matchRate(rates, destination, line.TaxClass)
+		if !ok || rate.Rate == 0 {
+			continue
+		}
+
+		taxLine := entities.TaxLine{
+			LineIndex: line.LineIndex,
+			StoreID:   line.StoreID,
+			Name:      rate.Name,
+			Rate:      rate.Rate,
+			Included:  line.PricesIncludeTax,
+		}
+		if line.PricesIncludeTax {
+			// Выделяем налог из цены: amount * rate / (100 + rate)
+			taxLine.Amount = utils.RoundPrice(line.Amount * rate.Rate / (100 + rate.Rate))
+			taxLine.TaxableAmount = utils.RoundPrice(line.Amount - taxLine.Amount)
+		} else {
+			taxLine.TaxableAmount = line.Amount
+			taxLine.Amount = utils.RoundPrice(line.Amount * rate.Rate / 100)
+		}
+		taxLines = append(taxLines, taxLine)
+	}
+	return taxLines, nil
+}
+
+// matchRate выбирает ставку для адреса и налогового класса
+func matchRate(rates []entities.TaxRate, destination entities.ShippingDestination, taxClass string) (entities.TaxRate, bool) {
+	var countryRate *entities.TaxRate
+	for i, rate := range rates {
+		if rate.TaxClass != taxClass || !strings.EqualFold(rate.Country, destination.Country) {
+			continue
+		}
+		if rate.Region == "" {
+			countryRate = &rates[i]
+		} else if strings.EqualFold(rate.Region, destination.Region) {
+			return rate, true
+		}
+	}
+	if countryRate != nil {
+		return *countryRate, true
+	}
+	return entities.TaxRate{}, false
+}
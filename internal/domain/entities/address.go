@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// Address адрес из адресной книги покупателя
+type Address struct {
+	ID                uint64    `json:"id"`
+	UserID            uint64    `json:"user_id"`
+	Recipient         string    `json:"recipient" validate:"required,max=200"`
+	Phone             string    `json:"phone" validate:"required,e164"`
+	Country           string    `json:"country" validate:"required,iso3166_1_alpha2"`
+	Region            string    `json:"region,omitempty" validate:"max=200"`
+	City              string    `json:"city" validate:"required,max=200"`
+	Street            string    `json:"street" validate:"required,max=500"`
+	Postcode          string    `json:"postcode,omitempty" validate:"max=20"`
+	IsDefaultBilling  bool      `json:"is_default_billing"`
+	IsDefaultShipping bool      `json:"is_default_shipping"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Destination возвращает адрес в виде, используемом для расчета доставки
+func (a Address) Destination() ShippingDestination {
+	return ShippingDestination{Country: a.Country, Region: a.Region, Postcode: a.Postcode}
+}
+
+// Snapshot возвращает копию адреса, не связанную с адресной книгой,
+// чтобы последующие изменения адреса не влияли на сохраненные документы
+func (a Address) Snapshot() Address {
+	a.ID = 0
+	a.IsDefaultBilling = false
+	a.IsDefaultShipping = false
+	return a
+}
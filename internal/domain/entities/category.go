@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// Category узел дерева категорий товаров
+type Category struct {
+	ID                 uint64     `json:"id" validate:"required"`
+	ParentID           uint64     `json:"parent_id,omitempty"` // 0 для корневой категории
+	Name               string     `json:"name" validate:"required"`
+	Slug               string     `json:"slug" validate:"required,slug"`
+	Position           int        `json:"position"`                      // Порядок среди соседних категорий
+	RequiredAttributes []string   `json:"required_attributes,omitempty"` // Атрибуты, обязательные для продуктов категории
+	Children           []Category `json:"children,omitempty"`            // Заполняется только при выдаче дерева
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// Conversation переписка покупателя с магазином, при необходимости о конкретном продукте
+type Conversation struct {
+	ID            uint64    `json:"id"`
+	StoreID       uint64    `json:"store_id" validate:"required"`
+	BuyerID       uint64    `json:"buyer_id"`
+	ProductID     uint64    `json:"product_id,omitempty"`
+	UnreadCount   int       `json:"unread_count"` // Непрочитанные текущим пользователем сообщения, заполняется при выдаче
+	LastMessageAt time.Time `json:"last_message_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Message сообщение в переписке
+type Message struct {
+	ID             uint64              `json:"id"`
+	ConversationID uint64              `json:"conversation_id"`
+	SenderID       uint64              `json:"sender_id"`
+	Text           string              `json:"text" validate:"max=5000"`
+	Attachments    []MessageAttachment `json:"attachments,omitempty"`
+	ReadAt         *time.Time          `json:"read_at,omitempty"` // Время прочтения получателем
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// MessageAttachment файл, приложенный к сообщению
+type MessageAttachment struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"` // Путь API, по которому вложение доступно участникам переписки
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+}
+
+// StartConversationRequest запрос на начало переписки с магазином с первым сообщением
+type StartConversationRequest struct {
+	StoreID   uint64 `json:"store_id" validate:"required"`
+	ProductID uint64 `json:"product_id,omitempty"`
+	Text      string `json:"text" validate:"required,max=5000"`
+}
+
+// UnreadCount количество непрочитанных сообщений пользователя
+type UnreadCount struct {
+	Unread int `json:"unread"`
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// Coupon промокод магазина
+type Coupon struct {
+	ID                uint64             `json:"id"`
+	StoreID           uint64             `json:"store_id"`
+	Code              string             `json:"code" validate:"required,alphanum,max=32"`
+	Type              enums.DiscountType `json:"type" validate:"required,oneof=percentage fixed"`
+	Value             float64            `json:"value" validate:"required,gt=0"` // Процент или сумма в базовой валюте
+	MinOrderValue     float64            `json:"min_order_value" validate:"gte=0"`
+	UsageLimit        int                `json:"usage_limit" validate:"gte=0"`          // 0 — без ограничений
+	UsageLimitPerUser int                `json:"usage_limit_per_user" validate:"gte=0"` // 0 — без ограничений
+	StartsAt          time.Time          `json:"starts_at"`
+	EndsAt            time.Time          `json:"ends_at"` // Нулевое значение — без срока окончания
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// IsActive проверяет, что промокод действует в указанный момент
+func (c Coupon) IsActive(at time.Time) bool {
+	return !at.Before(c.StartsAt) && (c.EndsAt.IsZero() || at.Before(c.EndsAt))
+}
+
+// CouponRedemption факт использования промокода пользователем
+type CouponRedemption struct {
+	CouponID   uint64    `json:"coupon_id"`
+	UserID     uint64    `json:"user_id"`
+	RedeemedAt time.Time `json:"redeemed_at"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "marketplace/internal/domain/enums"
+
+// EmailMessage письмо, готовое к отправке
+type EmailMessage struct {
+	To      string
+	Subject string
+	Text    string // Текстовая версия письма
+	HTML    string // HTML-версия письма
+}
+
+// NotificationPreferences настройки уведомлений пользователя. Письма, относящиеся
+// к учетной записи (регистрация, безопасность), отправляются всегда.
+type NotificationPreferences struct {
+	UserID         uint64       `json:"-"`
+	Locale         enums.Locale `json:"locale" validate:"required,oneof=ru en"`
+	MessageEmails  bool         `json:"message_emails"`  // Письма о новых сообщениях от магазинов и покупателей
+	WishlistEmails bool         `json:"wishlist_emails"` // Письма о снижении цен и поступлении товаров из списков желаний
+}
+
+// DefaultNotificationPreferences возвращает настройки пользователя, который их не менял
+func DefaultNotificationPreferences(userID uint64) NotificationPreferences {
+	return NotificationPreferences{
+		UserID:         userID,
+		Locale:         enums.RussianLocale,
+		MessageEmails:  true,
+		WishlistEmails: true,
+	}
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// Event событие для доставки клиентам в реальном времени
+type Event struct {
+	ID        string          `json:"id"` // Возрастающий идентификатор в потоке топика, используется для возобновления
+	Topic     string          `json:"-"`
+	Type      enums.EventType `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// UserTopic возвращает топик личных событий пользователя
+func UserTopic(userID uint64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// StoreTopic возвращает топик событий магазина для панели продавца
+func StoreTopic(storeID uint64) string {
+	return fmt.Sprintf("store:%d", storeID)
+}
+
+// StockChange изменение остатка варианта продукта
+type StockChange struct {
+	ProductID     uint64 `json:"product_id"`
+	SKU           string `json:"sku"`
+	PreviousStock int    `json:"previous_stock"`
+	Stock         int    `json:"stock"`
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "time"
+
+// ExchangeRate курс валюты относительно базовой валюты маркетплейса
+type ExchangeRate struct {
+	Currency      string    `json:"currency" validate:"required,iso4217"`
+	Rate          float64   `json:"rate" validate:"required,gt=0"` // Количество единиц валюты за одну единицу базовой валюты
+	EffectiveFrom time.Time `json:"effective_from" validate:"required"`
+}
@@ -0,0 +1,12 @@
+package entities
+
+import "net/http"
+
+// IdempotentResponse сохраненный ответ на запрос с ключом идемпотентности,
+// который возвращается при повторе запроса
+type IdempotentResponse struct {
+	RequestHash string      `json:"request_hash"` // Хэш метода, пути и тела исходного запроса
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+}
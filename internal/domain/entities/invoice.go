@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// InvoiceInput данные заказа, по которым магазин выставляет счет. Заказов пока нет,
+// поэтому передаются позиции корзины покупателя; цены, скидки и налоги по ним
+// рассчитывает сервер. Все позиции должны относиться к магазину.
+type InvoiceInput struct {
+	Reference string `json:"reference" validate:"required,max=64"` // Номер заказа или платежа
+	BuyerID   uint64 `json:"buyer_id" validate:"required"`
+	PricingRequest
+}
+
+// InvoiceSeller реквизиты продавца на момент выставления счета
+type InvoiceSeller struct {
+	StoreName    string `json:"store_name"`
+	LegalName    string `json:"legal_name,omitempty"`
+	TaxID        string `json:"tax_id,omitempty"`
+	LegalAddress string `json:"legal_address,omitempty"`
+}
+
+// Invoice выставленный счет. После выставления не изменяется; PDF хранится в закрытом хранилище.
+type Invoice struct {
+	ID            uint64            `json:"id"`
+	Number        string            `json:"number"` // Сквозной номер в пределах магазина
+	StoreID       uint64            `json:"store_id"`
+	BuyerID       uint64            `json:"buyer_id"`
+	Reference     string            `json:"reference"`
+	Seller        InvoiceSeller     `json:"seller"`
+	Lines         []PricedLine      `json:"lines"`
+	Discounts     []AppliedDiscount `json:"discounts"`
+	TaxLines      []TaxLine         `json:"tax_lines"` // LineIndex указывает на позицию счета
+	Subtotal      float64           `json:"subtotal"`
+	DiscountTotal float64           `json:"discount_total"`
+	TaxTotal      float64           `json:"tax_total"`
+	Total         float64           `json:"total"`
+	Currency      string            `json:"currency"`
+	IssuedAt      time.Time         `json:"issued_at"`
+}
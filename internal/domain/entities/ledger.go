@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// Счета журнала расчетов
+const (
+	MarketplaceCashAccount       = "marketplace_cash"       // Деньги покупателей на счете маркетплейса
+	MarketplaceCommissionAccount = "marketplace_commission" // Заработанная комиссия маркетплейса
+	StorePayableAccount          = "store_payable"          // Задолженность маркетплейса перед магазином
+)
+
+// LedgerPosting проводка по одному счету; у проводки заполнен либо Debit, либо Credit
+type LedgerPosting struct {
+	Account string  `json:"account"`
+	StoreID uint64  `json:"store_id,omitempty"` // Для счетов магазина
+	Debit   float64 `json:"debit"`
+	Credit  float64 `json:"credit"`
+}
+
+// LedgerTransaction неизменяемая запись журнала; сумма дебетов равна сумме кредитов
+type LedgerTransaction struct {
+	ID          uint64                      `json:"id"`
+	Type        enums.LedgerTransactionType `json:"type"`
+	StoreID     uint64                      `json:"store_id"`
+	Reference   string                      `json:"reference"` // Например, номер заказа или платежа
+	Description string                      `json:"description,omitempty"`
+	Postings    []LedgerPosting             `json:"postings"`
+	CreatedAt   time.Time                   `json:"created_at"`
+}
+
+// Amount возвращает сумму движения по счету магазина
+func (t LedgerTransaction) Amount() float64 {
+	var amount float64
+	for _, posting := range t.Postings {
+		if posting.Account == StorePayableAccount {
+			amount += posting.Credit - posting.Debit
+		}
+	}
+	return amount
+}
+
+// CommissionRule ставка комиссии маркетплейса для магазина или категории
+type CommissionRule struct {
+	ID         uint64    `json:"id"`
+	StoreID    uint64    `json:"store_id,omitempty" validate:"required_without=CategoryID,excluded_with=CategoryID"`
+	CategoryID uint64    `json:"category_id,omitempty"`
+	Rate       float64   `json:"rate" validate:"gte=0,lte=100"` // Процент от суммы позиции
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PaymentRecord оплата покупателя, поступившая на счет маркетплейса
+type PaymentRecord struct {
+	Reference string     `json:"reference" validate:"required"`
+	Cart      PricedCart `json:"cart"`
+}
+
+// RefundRecord возврат части или всей оплаты магазина покупателю
+type RefundRecord struct {
+	Reference string  `json:"reference" validate:"required"` // Reference исходной оплаты
+	StoreID   uint64  `json:"store_id" validate:"required"`
+	Amount    float64 `json:"amount" validate:"gt=0"`
+}
+
+// StoreBalance остаток задолженности маркетплейса перед магазином
+type StoreBalance struct {
+	StoreID    uint64  `json:"store_id"`
+	Balance    float64 `json:"balance"`
+	Paid       float64 `json:"paid"`       // Оплачено покупателями
+	Commission float64 `json:"commission"` // Удержано комиссии
+	Refunded   float64 `json:"refunded"`   // Возвращено покупателям
+	PaidOut    float64 `json:"paid_out"`   // Выплачено магазину
+	Currency   string  `json:"currency"`
+}
+
+// Payout выплата магазину в составе пакета
+type Payout struct {
+	StoreID       uint64  `json:"store_id"`
+	Amount        float64 `json:"amount"`
+	TransactionID uint64  `json:"transaction_id"`
+}
+
+// PayoutBatch пакет выплат продавцам, сформированный за один запуск
+type PayoutBatch struct {
+	ID        uint64    `json:"id"`
+	Payouts   []Payout  `json:"payouts"`
+	Total     float64   `json:"total"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReconciliationReport сверка журнала за период
+type ReconciliationReport struct {
+	From                     time.Time                               `json:"from"`
+	To                       time.Time                               `json:"to"`
+	TransactionCount         int                                     `json:"transaction_count"`
+	DebitTotal               float64                                 `json:"debit_total"`
+	CreditTotal              float64                                 `json:"credit_total"`
+	Balanced                 bool                                    `json:"balanced"`
+	UnbalancedTransactionIDs []uint64                                `json:"unbalanced_transaction_ids"`
+	Totals                   map[enums.LedgerTransactionType]float64 `json:"totals"`             // Движение денег маркетплейса по видам
+	AccountBalances          map[string]float64                      `json:"account_balances"`   // Кредит минус дебет по счетам
+	PayoutBatchTotal         float64                                 `json:"payout_batch_total"` // Должна совпадать с Totals[payout]
+}
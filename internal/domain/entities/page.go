@@ -0,0 +1,32 @@
+package entities
+
+// Page страница результатов постраничной выдачи
+type Page[T any] struct {
+	Items   []T `json:"items"`
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// NewPage вырезает из items страницу page (нумерация с 1) размером perPage.
+// Страница за пределами выдачи пустая; номер страницы сравнивается с числом
+// страниц до умножения, чтобы большой page не переполнил смещение.
+func NewPage[T any](items []T, page, perPage int) Page[T] {
+	start := len(items)
+	if page >= 1 && perPage >= 1 {
+		pages := len(items) / perPage
+		if len(items)%perPage != 0 {
+			pages++
+		}
+		if page <= pages {
+			start = (page - 1) * perPage
+		}
+	}
+	end := start + min(perPage, len(items)-start)
+	return Page[T]{
+		Items:   append([]T{}, items[start:end]...),
+		Total:   len(items),
+		Page:    page,
+		PerPage: perPage,
+	}
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNewPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		name          string
+		page, perPage int
+		want          []int
+	}{
+		{"first page", 1, 2, []int{1, 2}},
+		{"last partial page", 3, 2, []int{5}},
+		{"page after the last", 4, 2, []int{}},
+		{"page overflowing the offset", math.MaxInt64/16 + 1, 16, []int{}},
+		{"largest page size", 1, math.MaxInt, []int{1, 2, 3, 4, 5}},
+		{"largest page and size", math.MaxInt, math.MaxInt, []int{}},
+		{"zero page", 0, 2, []int{}},
+		{"zero page size", 1, 0, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewPage(items, tt.page, tt.perPage)
+			if !slices.Equal(page.Items, tt.want) {
+				t.Errorf("NewPage(%d, %d).Items = %v, want %v", tt.page, tt.perPage, page.Items, tt.want)
+			}
+			if page.Total != len(items) {
+				t.Errorf("NewPage(%d, %d).Total = %d, want %d", tt.page, tt.perPage, page.Total, len(items))
+			}
+		})
+	}
+}
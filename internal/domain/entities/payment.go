@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// PaymentInput данные оплаты счета покупателем
+type PaymentInput struct {
+	Token string `json:"token" validate:"required,max=256"` // Токен способа оплаты, полученный клиентом у платежного провайдера
+}
+
+// PaymentCharge списание денег покупателя через платежного провайдера
+type PaymentCharge struct {
+	Reference string
+	Amount    float64
+	Currency  string
+	Token     string
+}
+
+// Payment оплата счета, подтвержденная платежным провайдером
+type Payment struct {
+	ID                uint64    `json:"id"`
+	InvoiceID         uint64    `json:"invoice_id"`
+	StoreID           uint64    `json:"store_id"`
+	BuyerID           uint64    `json:"buyer_id"`
+	Reference         string    `json:"reference"` // Номер счета; по нему проводятся движения журнала
+	Amount            float64   `json:"amount"`
+	Refunded          float64   `json:"refunded"`
+	Currency          string    `json:"currency"`
+	ProviderPaymentID string    `json:"provider_payment_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
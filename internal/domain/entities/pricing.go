@@ -0,0 +1,61 @@
+package entities
+
+// CartLine позиция корзины, передаваемая в расчет цены
+type CartLine struct {
+	ProductID uint64 `json:"product_id" validate:"required"`
+	SKU       string `json:"sku,omitempty"` // Артикул варианта, если у продукта есть варианты
+	Quantity  int    `json:"quantity" validate:"required,min=1"`
+}
+
+// PricingRequest запрос на расчет стоимости корзины
+type PricingRequest struct {
+	Lines       []CartLine           `json:"lines" validate:"required,min=1,dive"`
+	CouponCodes []string             `json:"coupon_codes,omitempty"`
+	AddressID   uint64               `json:"address_id,omitempty"`  // Адрес из адресной книги вместо destination
+	Destination *ShippingDestination `json:"destination,omitempty"` // Без адреса налоги не рассчитываются
+}
+
+// PricedLine позиция корзины с рассчитанной ценой и скидкой
+type PricedLine struct {
+	ProductID uint64  `json:"product_id"`
+	SKU       string  `json:"sku,omitempty"`
+	StoreID   uint64  `json:"store_id"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Subtotal  float64 `json:"subtotal"` // UnitPrice * Quantity
+	Discount  float64 `json:"discount"` // Сумма всех скидок, отнесенных на позицию
+	Total     float64 `json:"total"`    // Subtotal - Discount
+	TaxClass  string  `json:"tax_class"`
+	Tax       float64 `json:"tax"` // Налог по позиции, включенный в Total или начисляемый сверху
+}
+
+// Источники скидок
+const (
+	PromotionDiscountSource = "promotion"
+	CouponDiscountSource    = "coupon"
+)
+
+// AppliedDiscount примененная скидка для детализации в ответе
+type AppliedDiscount struct {
+	Source    string  `json:"source"` // PromotionDiscountSource или CouponDiscountSource
+	ID        uint64  `json:"id"`
+	Code      string  `json:"code,omitempty"`
+	Name      string  `json:"name,omitempty"`
+	StoreID   uint64  `json:"store_id"`
+	LineIndex *int    `json:"line_index,omitempty"` // Позиция, к которой относится акция; нет для промокодов
+	Amount    float64 `json:"amount"`
+}
+
+// PricedCart результат расчета стоимости корзины
+type PricedCart struct {
+	Lines         []PricedLine         `json:"lines"`
+	Discounts     []AppliedDiscount    `json:"discounts"`
+	TaxLines      []TaxLine            `json:"tax_lines"`
+	Subtotal      float64              `json:"subtotal"`
+	DiscountTotal float64              `json:"discount_total"`
+	TaxTotal      float64              `json:"tax_total"` // Все налоги, включая уже содержащиеся в ценах
+	Total         float64              `json:"total"`     // К оплате: сумма после скидок плюс налоги, не включенные в цены
+	Currency      string               `json:"currency"`
+	Destination   *ShippingDestination `json:"destination,omitempty"`
+}
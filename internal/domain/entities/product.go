@@ -7,9 +7,43 @@ type Product struct {
 	Name        string    `json:"name,omitempty"`
 	Description string    `json:"description,omitempty"`
 	Price       float64   `json:"price,omitempty"`
+	Currency    string    `json:"currency,omitempty"` // Валюта отображения цены, заполняется при выдаче
 	OwnerID     uint64    `json:"owner_id,omitempty"`
 	StoreID     uint64    `json:"store_id,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	Status      string    `json:"status,omitempty"` // Доступен, Продан и т.д.
+
+	Options  []ProductOption  `json:"options,omitempty" validate:"dive"`
+	Variants []ProductVariant `json:"variants,omitempty" validate:"dive"`
+
+	CategoryIDs []uint64          `json:"category_ids,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"` // Атрибуты, требуемые категориями (бренд, материал и т.д.)
+
+	Images []ProductImage `json:"images,omitempty"` // Управляются через /products/:id/images
+
+	Rating      float64 `json:"rating,omitempty"` // Средняя оценка по отзывам, поддерживается ReviewUseCase
+	ReviewCount int     `json:"review_count,omitempty"`
+
+	WeightGrams int                `json:"weight_grams,omitempty" validate:"gte=0"`
+	Dimensions  *ProductDimensions `json:"dimensions,omitempty"`
+
+	TaxClass string `json:"tax_class,omitempty" validate:"omitempty,oneof=standard reduced zero"` // Пусто — standard
+}
+
+// ProductDimensions габариты упаковки продукта в сантиметрах
+type ProductDimensions struct {
+	LengthCm float64 `json:"length_cm" validate:"gt=0"`
+	WidthCm  float64 `json:"width_cm" validate:"gt=0"`
+	HeightCm float64 `json:"height_cm" validate:"gt=0"`
+}
+
+// FindVariant возвращает вариант продукта по артикулу
+func (p Product) FindVariant(sku string) (ProductVariant, bool) {
+	for _, variant := range p.Variants {
+		if variant.SKU == sku {
+			return variant, true
+		}
+	}
+	return ProductVariant{}, false
 }
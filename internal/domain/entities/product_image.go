@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+// ProductImage изображение продукта с миниатюрами разных размеров
+type ProductImage struct {
+	ID          string            `json:"id"`
+	URL         string            `json:"url"`
+	ContentType string            `json:"content_type"`
+	Thumbnails  map[string]string `json:"thumbnails"` // Название размера -> URL миниатюры
+	CreatedAt   time.Time         `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+// ProductOption опция продукта (размер, цвет и т.д.) с допустимыми значениями
+type ProductOption struct {
+	Name   string   `json:"name" validate:"required"`
+	Values []string `json:"values" validate:"required,min=1,dive,required"`
+}
+
+// ProductVariant вариант продукта с собственным артикулом, ценой и остатком
+type ProductVariant struct {
+	SKU     string            `json:"sku" validate:"required"`
+	Options map[string]string `json:"options"`                                   // Название опции -> значение
+	Price   *float64          `json:"price,omitempty" validate:"omitempty,gt=0"` // Переопределяет цену продукта
+	Stock   int               `json:"stock" validate:"gte=0"`
+}
+
+// EffectivePrice возвращает цену варианта с учетом цены родительского продукта
+func (v ProductVariant) EffectivePrice(product Product) float64 {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return product.Price
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// Promotion автоматическая акция магазина, применяемая без промокода
+type Promotion struct {
+	ID           uint64              `json:"id"`
+	StoreID      uint64              `json:"store_id"`
+	Name         string              `json:"name" validate:"required"`
+	Type         enums.PromotionType `json:"type" validate:"required,oneof=buy_x_get_y percentage_off"`
+	CategoryID   uint64              `json:"category_id,omitempty"`                      // Ограничивает акцию категорией и ее потомками
+	Percent      float64             `json:"percent,omitempty" validate:"gte=0,lte=100"` // Для percentage_off
+	BuyQuantity  int                 `json:"buy_quantity,omitempty" validate:"gte=0"`    // Для buy_x_get_y
+	FreeQuantity int                 `json:"free_quantity,omitempty" validate:"gte=0"`   // Для buy_x_get_y
+	Priority     int                 `json:"priority"`                                   // При равной выгоде побеждает больший приоритет
+	StartsAt     time.Time           `json:"starts_at"`
+	EndsAt       time.Time           `json:"ends_at"` // Нулевое значение — без срока окончания
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// IsActive проверяет, что акция действует в указанный момент
+func (p Promotion) IsActive(at time.Time) bool {
+	return !at.Before(p.StartsAt) && (p.EndsAt.IsZero() || at.Before(p.EndsAt))
+}
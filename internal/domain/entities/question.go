@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// Question вопрос покупателя о продукте
+type Question struct {
+	ID          uint64                 `json:"id"`
+	ProductID   uint64                 `json:"product_id"`
+	StoreID     uint64                 `json:"store_id"`
+	UserID      uint64                 `json:"user_id"`
+	Text        string                 `json:"text" validate:"required,max=2000"`
+	Status      enums.ModerationStatus `json:"status"`
+	AnswerCount int                    `json:"answer_count"` // Количество опубликованных ответов, заполняется при выдаче
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// Answer ответ продавца на вопрос о продукте
+type Answer struct {
+	ID         uint64                 `json:"id"`
+	QuestionID uint64                 `json:"question_id"`
+	UserID     uint64                 `json:"user_id"`
+	Text       string                 `json:"text" validate:"required,max=5000"`
+	Status     enums.ModerationStatus `json:"status"`
+	Upvotes    int                    `json:"upvotes"` // Количество отметок «полезно», заполняется при выдаче
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+// ModerationDecision решение администратора по вопросу или ответу
+type ModerationDecision struct {
+	Status enums.ModerationStatus `json:"status" validate:"required,oneof=published pending rejected"`
+}
@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// RateLimitResult результат учета запроса ограничителем частоты
+type RateLimitResult struct {
+	Allowed   bool          // Запрос укладывается в лимит
+	Limit     int           // Сколько запросов разрешено за окно
+	Remaining int           // Сколько запросов еще можно сделать в текущем окне
+	Reset     time.Duration // Через сколько освободится место для следующего запроса
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// ReturnRequestInput заявка покупателя на возврат позиции. Заказов пока нет,
+// поэтому возврат оформляется по позиции выставленного магазином счета.
+type ReturnRequestInput struct {
+	LineIndex int    `json:"line_index" validate:"gte=0"` // Номер позиции в счете, с нуля
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+	Reason    string `json:"reason" validate:"required,max=1000"`
+}
+
+// ReturnDecision решение продавца по заявке на возврат
+type ReturnDecision struct {
+	Comment string `json:"comment" validate:"max=1000"`
+}
+
+// ReturnShipment отправка возвращаемого товара продавцу
+type ReturnShipment struct {
+	Carrier        string `json:"carrier" validate:"required,max=64"`
+	TrackingNumber string `json:"tracking_number" validate:"required,max=64"`
+}
+
+// ReturnRefundInput сумма возврата денег; 0 — вся стоимость возвращаемых единиц
+type ReturnRefundInput struct {
+	Amount float64 `json:"amount" validate:"gte=0"`
+}
+
+// ReturnRequest заявка на возврат позиции счета
+type ReturnRequest struct {
+	ID            uint64             `json:"id"`
+	InvoiceID     uint64             `json:"invoice_id"`
+	StoreID       uint64             `json:"store_id"`
+	BuyerID       uint64             `json:"buyer_id"`
+	LineIndex     int                `json:"line_index"`
+	ProductID     uint64             `json:"product_id"`
+	SKU           string             `json:"sku,omitempty"`
+	Quantity      int                `json:"quantity"`
+	Reason        string             `json:"reason"`
+	Photos        []ReturnPhoto      `json:"photos,omitempty"` // Загружаются через /returns/:id/photos
+	Status        enums.ReturnStatus `json:"status"`
+	SellerComment string             `json:"seller_comment,omitempty"`
+	Shipment      *ReturnShipment    `json:"shipment,omitempty"`
+	RefundAmount  float64            `json:"refund_amount,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// ReturnPhoto фотография к заявке; доступна только покупателю и продавцу
+type ReturnPhoto struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// Review отзыв покупателя о продукте
+type Review struct {
+	ID        uint64       `json:"id"`
+	ProductID uint64       `json:"product_id"`
+	StoreID   uint64       `json:"store_id"`
+	UserID    uint64       `json:"user_id"`
+	Rating    int          `json:"rating" validate:"required,min=1,max=5"`
+	Text      string       `json:"text" validate:"max=5000"`
+	Photos    []string     `json:"photos,omitempty"` // URL фотографий, загружаются через /reviews/:id/photos
+	Reply     *ReviewReply `json:"reply,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// ReviewReply ответ продавца на отзыв
+type ReviewReply struct {
+	UserID    uint64    `json:"user_id"`
+	Text      string    `json:"text" validate:"required,max=5000"`
+	CreatedAt time.Time `json:"created_at"`
+}
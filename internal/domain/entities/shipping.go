@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// ShippingProfile настройки доставки магазина
+type ShippingProfile struct {
+	StoreID   uint64           `json:"store_id"`
+	Methods   []ShippingMethod `json:"methods" validate:"dive"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// ShippingMethod способ доставки (курьер, пункт выдачи и т.д.) с тарифами по зонам
+type ShippingMethod struct {
+	Code    string         `json:"code" validate:"required,slug"`
+	Name    string         `json:"name" validate:"required"`
+	MinDays int            `json:"min_days" validate:"gte=0"`
+	MaxDays int            `json:"max_days" validate:"gtefield=MinDays"`
+	Rates   []ShippingRate `json:"rates" validate:"required,min=1,dive"`
+}
+
+// ShippingRate тариф способа доставки для зоны
+type ShippingRate struct {
+	Zone                  ShippingZone           `json:"zone"`
+	Type                  enums.ShippingRateType `json:"type" validate:"required,oneof=flat weight"`
+	Price                 float64                `json:"price" validate:"gte=0"`                   // Стоимость или базовая стоимость для weight
+	PricePerKg            float64                `json:"price_per_kg,omitempty" validate:"gte=0"`  // Для weight
+	FreeShippingThreshold float64                `json:"free_shipping_threshold" validate:"gte=0"` // 0 — бесплатной доставки нет
+}
+
+// ShippingZone зона доставки: страна и, при необходимости, регионы или префиксы индексов
+type ShippingZone struct {
+	Country          string   `json:"country" validate:"required,iso3166_1_alpha2"`
+	Regions          []string `json:"regions,omitempty"`
+	PostcodePrefixes []string `json:"postcode_prefixes,omitempty"`
+}
+
+// ShippingDestination адрес, для которого рассчитывается доставка
+type ShippingDestination struct {
+	Country  string `json:"country" validate:"required,iso3166_1_alpha2"`
+	Region   string `json:"region,omitempty"`
+	Postcode string `json:"postcode,omitempty"`
+}
+
+// ShippingQuoteRequest запрос на расчет доставки корзины
+type ShippingQuoteRequest struct {
+	PricingRequest // Адрес доставки задается через address_id или destination
+}
+
+// ShippingOption доступный способ доставки с рассчитанной стоимостью
+type ShippingOption struct {
+	MethodCode   string  `json:"method_code"`
+	Name         string  `json:"name"`
+	Cost         float64 `json:"cost"`
+	FreeShipping bool    `json:"free_shipping"`
+	MinDays      int     `json:"min_days"`
+	MaxDays      int     `json:"max_days"`
+}
+
+// StoreShippingQuote способы доставки позиций одного магазина
+type StoreShippingQuote struct {
+	StoreID     uint64           `json:"store_id"`
+	Subtotal    float64          `json:"subtotal"` // Сумма позиций магазина после скидок
+	WeightGrams int              `json:"weight_grams"`
+	Options     []ShippingOption `json:"options"`
+}
@@ -9,4 +9,13 @@ type Store struct {
 	OwnerID     uint64    `json:"owner_id"` // ID владельца магазина
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Rating      float64   `json:"rating"` // Средняя оценка по отзывам на продукты магазина
+	ReviewCount int       `json:"review_count"`
+
+	PricesIncludeTax bool `json:"prices_include_tax"` // Цены продуктов указаны с учетом налога
+
+	// Реквизиты продавца для счетов
+	LegalName    string `json:"legal_name,omitempty"`
+	TaxID        string `json:"tax_id,omitempty"` // ИНН
+	LegalAddress string `json:"legal_address,omitempty"`
 }
@@ -0,0 +1,38 @@
+package entities
+
+// Налоговые классы продуктов
+const (
+	StandardTaxClass = "standard"
+	ReducedTaxClass  = "reduced"
+	ZeroTaxClass     = "zero"
+)
+
+// TaxRate ставка налога для региона и налогового класса
+type TaxRate struct {
+	ID       uint64  `json:"id"`
+	Name     string  `json:"name" validate:"required"` // Например, «НДС 20%»
+	Country  string  `json:"country" validate:"required,iso3166_1_alpha2"`
+	Region   string  `json:"region,omitempty"` // Пусто — ставка действует во всей стране
+	TaxClass string  `json:"tax_class" validate:"required,oneof=standard reduced zero"`
+	Rate     float64 `json:"rate" validate:"gte=0,lte=100"` // Процент
+}
+
+// TaxableLine позиция, передаваемая в расчет налога
+type TaxableLine struct {
+	LineIndex        int     `json:"line_index"`
+	StoreID          uint64  `json:"store_id"`
+	TaxClass         string  `json:"tax_class"`
+	Amount           float64 `json:"amount"`             // Сумма позиции после скидок
+	PricesIncludeTax bool    `json:"prices_include_tax"` // Налог уже включен в Amount
+}
+
+// TaxLine рассчитанный налог по позиции
+type TaxLine struct {
+	LineIndex     int     `json:"line_index"`
+	StoreID       uint64  `json:"store_id"`
+	Name          string  `json:"name"`
+	Rate          float64 `json:"rate"`
+	TaxableAmount float64 `json:"taxable_amount"` // База налога без самого налога
+	Amount        float64 `json:"amount"`
+	Included      bool    `json:"included"` // Налог включен в цену позиции
+}
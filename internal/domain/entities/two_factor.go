@@ -0,0 +1,29 @@
+package entities
+
+// TwoFactorSetup секрет TOTP для добавления в приложение-аутентификатор
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth-ссылка для QR-кода
+}
+
+// TwoFactorCode код из приложения-аутентификатора или резервный код
+type TwoFactorCode struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// RecoveryCodes резервные коды; показываются пользователю один раз
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// MFAChallenge ответ на вход с паролем, когда требуется второй шаг
+type MFAChallenge struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+}
+
+// MFALogin второй шаг входа: токен из MFAChallenge и код
+type MFALogin struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
@@ -6,4 +6,39 @@ type User struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
 	IsSeller bool   `json:"is_seller" validate:"required"`
+	IsAdmin  bool   `json:"-"` // Назначается сервером по списку ADMIN_EMAILS после подтверждения email
+
+	EmailVerified bool `json:"email_verified"` // Устанавливается только по ссылке из письма
+
+	TwoFactorState
+}
+
+// TwoFactorState настройки двухфакторной аутентификации пользователя. Сохраняются
+// целиком через UserRepository.SetTwoFactor, не затрагивая остальные поля User.
+type TwoFactorState struct {
+	TwoFactorEnabled bool     `json:"two_factor_enabled"`
+	TOTPSecret       string   `json:"-"` // Секрет TOTP; до подтверждения первым кодом 2FA не включена
+	RecoveryCodes    []string `json:"-"` // bcrypt-хэши неиспользованных резервных кодов
+	TOTPLastStep     int64    `json:"-"` // Шаг времени последнего принятого кода; коды этого и прошлых шагов отклоняются
+}
+
+// PasswordResetRequest запрос ссылки для сброса пароля
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// PasswordReset новый пароль по токену из письма
+type PasswordReset struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,password"`
+}
+
+// EmailVerification токен подтверждения email из письма
+type EmailVerification struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// AccountUnlock токен разблокировки учетной записи из письма
+type AccountUnlock struct {
+	Token string `json:"token" validate:"required"`
 }
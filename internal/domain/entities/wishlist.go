@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+// Wishlist именованный список желаний пользователя
+type Wishlist struct {
+	ID         uint64                   `json:"id"`
+	UserID     uint64                   `json:"user_id"`
+	Name       string                   `json:"name" validate:"required,max=100"`
+	Visibility enums.WishlistVisibility `json:"visibility" validate:"required,oneof=private shared public"`
+	ShareToken string                   `json:"share_token,omitempty"` // Токен ссылки для списков с видимостью shared
+	Items      []WishlistItem           `json:"items"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+}
+
+// FindItem возвращает индекс позиции списка по продукту и артикулу варианта
+func (w Wishlist) FindItem(productID uint64, sku string) int {
+	for i, item := range w.Items {
+		if item.ProductID == productID && item.SKU == sku {
+			return i
+		}
+	}
+	return -1
+}
+
+// WishlistItem продукт или его вариант в списке желаний
+type WishlistItem struct {
+	ProductID uint64    `json:"product_id" validate:"required"`
+	SKU       string    `json:"sku,omitempty"` // Пусто — продукт целиком
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// Notification уведомление пользователя
+type Notification struct {
+	ID        uint64                 `json:"id"`
+	UserID    uint64                 `json:"user_id"`
+	Type      enums.NotificationType `json:"type"`
+	ProductID uint64                 `json:"product_id,omitempty"`
+	SKU       string                 `json:"sku,omitempty"`
+	OldPrice  float64                `json:"old_price,omitempty"`
+	NewPrice  float64                `json:"new_price,omitempty"`
+	Read      bool                   `json:"read"`
+	CreatedAt time.Time              `json:"created_at"`
+}
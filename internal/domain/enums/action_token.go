@@ -0,0 +1,30 @@
+package enums
+
+import (
+	"marketplace/pkg/constants"
+	"time"
+)
+
+// ActionToken назначение одноразового подписанного токена
+type ActionToken string
+
+const (
+	EmailVerificationToken ActionToken = "email_verification" // Подтверждение адреса электронной почты
+	PasswordResetToken     ActionToken = "password_reset"     // Сброс забытого пароля
+	MFAChallengeToken      ActionToken = "mfa_challenge"      // Второй шаг входа с двухфакторной аутентификацией
+	AccountUnlockToken     ActionToken = "account_unlock"     // Снятие блокировки после неудачных попыток входа
+)
+
+// Duration срок действия токена
+func (t ActionToken) Duration() time.Duration {
+	switch t {
+	case PasswordResetToken:
+		return constants.PasswordResetTokenLifetime
+	case MFAChallengeToken:
+		return constants.MFAChallengeLifetime
+	case AccountUnlockToken:
+		return constants.AccountUnlockTokenLifetime
+	default:
+		return constants.EmailVerificationTokenLifetime
+	}
+}
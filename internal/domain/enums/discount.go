@@ -0,0 +1,17 @@
+package enums
+
+// DiscountType способ расчета скидки по купону
+type DiscountType string
+
+const (
+	PercentageDiscount DiscountType = "percentage" // Процент от суммы
+	FixedDiscount      DiscountType = "fixed"      // Фиксированная сумма
+)
+
+// PromotionType вид автоматической акции магазина
+type PromotionType string
+
+const (
+	BuyXGetYPromotion      PromotionType = "buy_x_get_y"    // Например, «3 по цене 2»
+	PercentageOffPromotion PromotionType = "percentage_off" // Процентная скидка на товары магазина или категории
+)
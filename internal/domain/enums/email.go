@@ -0,0 +1,23 @@
+package enums
+
+// EmailTemplate шаблон транзакционного письма
+type EmailTemplate string
+
+const (
+	WelcomeEmail           EmailTemplate = "welcome"            // Приветствие после регистрации со ссылкой подтверждения
+	EmailVerificationEmail EmailTemplate = "email_verification" // Повторная ссылка подтверждения адреса
+	PasswordResetEmail     EmailTemplate = "password_reset"     // Ссылка для сброса пароля
+	PasswordChangedEmail   EmailTemplate = "password_changed"   // Пароль изменен, все сессии завершены
+	AccountLockedEmail     EmailTemplate = "account_locked"     // Учетная запись заблокирована, ссылка для разблокировки
+	NewMessageEmail        EmailTemplate = "new_message"        // Новое сообщение в переписке с магазином
+	PriceDropEmail         EmailTemplate = "price_drop"         // Снизилась цена товара из списка желаний
+	BackInStockEmail       EmailTemplate = "back_in_stock"      // Товар из списка желаний снова в наличии
+)
+
+// Locale язык писем пользователя
+type Locale string
+
+const (
+	RussianLocale Locale = "ru"
+	EnglishLocale Locale = "en"
+)
@@ -0,0 +1,15 @@
+package enums
+
+// EventType вид события, доставляемого клиентам в реальном времени
+type EventType string
+
+const (
+	OrderStatusChangedEvent EventType = "order.status_changed" // Изменился статус заказа
+	MessageCreatedEvent     EventType = "message.created"      // Новое сообщение в переписке
+	StockAlertEvent         EventType = "stock.alert"          // Товар из списка желаний снова в наличии
+	PriceDropEvent          EventType = "price.drop"           // Снизилась цена товара из списка желаний
+	ProductCreatedEvent     EventType = "product.created"      // В магазине создан продукт
+	ProductUpdatedEvent     EventType = "product.updated"      // Продукт магазина изменен
+	ProductDeletedEvent     EventType = "product.deleted"      // Продукт магазина удален
+	StockChangedEvent       EventType = "stock.changed"        // Изменился остаток варианта продукта
+)
@@ -0,0 +1,10 @@
+package enums
+
+// LedgerTransactionType вид денежного движения в журнале расчетов
+type LedgerTransactionType string
+
+const (
+	PaymentTransaction LedgerTransactionType = "payment" // Оплата покупателя с удержанием комиссии маркетплейса
+	RefundTransaction  LedgerTransactionType = "refund"  // Возврат покупателю с возвратом комиссии
+	PayoutTransaction  LedgerTransactionType = "payout"  // Выплата продавцу
+)
@@ -0,0 +1,20 @@
+package enums
+
+// LoginAttemptScope по какому признаку считаются неудачные попытки входа
+type LoginAttemptScope string
+
+const (
+	AccountScope LoginAttemptScope = "account" // Учетная запись, к которой пытаются подобрать пароль
+	IPScope      LoginAttemptScope = "ip"      // Адрес, с которого приходят попытки
+)
+
+// SecurityEvent событие безопасности, записываемое в журнал для аудита
+type SecurityEvent string
+
+const (
+	LoginSucceededEvent  SecurityEvent = "login_succeeded"  // Успешный вход
+	LoginFailedEvent     SecurityEvent = "login_failed"     // Неверный пароль или код второго фактора
+	LoginThrottledEvent  SecurityEvent = "login_throttled"  // Попытка входа отклонена из-за задержки или блокировки
+	AccountLockedEvent   SecurityEvent = "account_locked"   // Учетная запись заблокирована после серии неудачных попыток
+	AccountUnlockedEvent SecurityEvent = "account_unlocked" // Блокировка снята по ссылке из письма или сбросом пароля
+)
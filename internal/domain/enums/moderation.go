@@ -0,0 +1,10 @@
+package enums
+
+// ModerationStatus состояние пользовательского контента после модерации
+type ModerationStatus string
+
+const (
+	PublishedContent ModerationStatus = "published" // Виден всем пользователям
+	PendingContent   ModerationStatus = "pending"   // Ожидает проверки администратором
+	RejectedContent  ModerationStatus = "rejected"  // Скрыт модерацией
+)
@@ -0,0 +1,14 @@
+package enums
+
+// ReturnStatus состояние заявки на возврат. Переходы:
+// requested → approved | rejected, approved → shipped → received → refunded.
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "requested" // Покупатель оформил заявку
+	ReturnApproved  ReturnStatus = "approved"  // Продавец согласился принять товар
+	ReturnRejected  ReturnStatus = "rejected"  // Продавец отказал в возврате
+	ReturnShipped   ReturnStatus = "shipped"   // Покупатель отправил товар продавцу
+	ReturnReceived  ReturnStatus = "received"  // Продавец получил товар, остаток пополнен
+	ReturnRefunded  ReturnStatus = "refunded"  // Деньги возвращены покупателю
+)
@@ -0,0 +1,9 @@
+package enums
+
+// ShippingRateType способ расчета стоимости доставки
+type ShippingRateType string
+
+const (
+	FlatShippingRate   ShippingRateType = "flat"   // Фиксированная стоимость
+	WeightShippingRate ShippingRateType = "weight" // Базовая стоимость плюс цена за каждый начатый килограмм
+)
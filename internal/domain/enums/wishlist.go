@@ -0,0 +1,18 @@
+package enums
+
+// WishlistVisibility кто может просматривать список желаний
+type WishlistVisibility string
+
+const (
+	PrivateWishlist WishlistVisibility = "private" // Только владелец
+	SharedWishlist  WishlistVisibility = "shared"  // Владелец и все, у кого есть ссылка
+	PublicWishlist  WishlistVisibility = "public"  // Все пользователи
+)
+
+// NotificationType вид уведомления пользователя
+type NotificationType string
+
+const (
+	PriceDropNotification   NotificationType = "price_drop"    // Снизилась цена товара из списка желаний
+	BackInStockNotification NotificationType = "back_in_stock" // Товар из списка желаний снова в наличии
+)
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"marketplace/internal/domain/enums"
+)
+
+type ActionTokenRepository interface {
+	Save(purpose enums.ActionToken, tokenID string, userID uint64) error
+	Consume(purpose enums.ActionToken, tokenID string) (uint64, error)
+}
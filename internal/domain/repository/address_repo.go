@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type AddressRepository interface {
+	Save(address entities.Address) (entities.Address, error)
+	FindByID(id uint64) (entities.Address, error)
+	Update(address entities.Address) error
+	Delete(id uint64) error
+	FindAllByUser(userID uint64) ([]entities.Address, error)
+}
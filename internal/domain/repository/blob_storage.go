@@ -0,0 +1,17 @@
+package repository
+
+// BlobStorage хранилище бинарных файлов (изображения, документы, вложения)
+type BlobStorage interface {
+	Put(key string, data []byte, contentType string) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+	URL(key string) string
+}
+
+// PrivateBlobStorage хранилище файлов без публичных ссылок (вложения переписки).
+// Файлы отдаются клиентам только обработчиками, проверяющими права доступа.
+type PrivateBlobStorage interface {
+	Put(key string, data []byte, contentType string) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+}
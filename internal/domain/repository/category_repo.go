@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type CategoryRepository interface {
+	// Save и Update отклоняют категорию, если ее slug занят другой категорией
+	Save(category entities.Category) error
+	FindByID(id uint64) (entities.Category, error)
+	FindBySlug(slug string) (entities.Category, error)
+	Update(category entities.Category) error
+	Delete(id uint64) error
+	FindAll() ([]entities.Category, error)
+}
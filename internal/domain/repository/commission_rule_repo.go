@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type CommissionRuleRepository interface {
+	Save(rule entities.CommissionRule) (entities.CommissionRule, error)
+	Delete(id uint64) error
+	FindAll() ([]entities.CommissionRule, error)
+}
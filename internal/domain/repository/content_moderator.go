@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"marketplace/internal/domain/enums"
+)
+
+// ContentModerator проверяет пользовательский текст перед публикацией.
+// Реализация может быть заменена внешним сервисом модерации.
+type ContentModerator interface {
+	Moderate(text string) (enums.ModerationStatus, error)
+}
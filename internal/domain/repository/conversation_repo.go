@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+	"time"
+)
+
+type ConversationRepository interface {
+	Save(conversation entities.Conversation) (entities.Conversation, error)
+	FindByID(id uint64) (entities.Conversation, error)
+	Find(storeID, buyerID, productID uint64) (entities.Conversation, error)
+	FindAllByParticipant(userID uint64, storeIDs []uint64) ([]entities.Conversation, error)
+	SaveMessage(message entities.Message) (entities.Message, error)
+	FindMessages(conversationID uint64) ([]entities.Message, error)
+	MarkRead(conversationID, readerID uint64, at time.Time) error
+}
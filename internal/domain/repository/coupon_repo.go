@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type CouponRepository interface {
+	Save(coupon entities.Coupon) (entities.Coupon, error)
+	FindByID(id uint64) (entities.Coupon, error)
+	FindByCode(code string) (entities.Coupon, error)
+	Delete(id uint64) error
+	FindAllByStore(storeID uint64) ([]entities.Coupon, error)
+	SaveRedemptions(redemptions []entities.CouponRedemption) error
+	CountRedemptions(couponID uint64) (int, error)
+	CountUserRedemptions(couponID, userID uint64) (int, error)
+}
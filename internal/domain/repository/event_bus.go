@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"marketplace/internal/domain/entities"
+)
+
+// EventBus доставляет события всем экземплярам сервера и хранит ограниченную
+// историю каждого топика для возобновления доставки после переподключения
+type EventBus interface {
+	Publish(event entities.Event) (entities.Event, error)
+	Replay(topic, afterID string) ([]entities.Event, error)
+	Subscribe(ctx context.Context, topic string) (<-chan entities.Event, error)
+	Close() error
+}
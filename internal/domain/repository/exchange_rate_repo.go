@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+	"time"
+)
+
+type ExchangeRateRepository interface {
+	Save(rate entities.ExchangeRate) error
+	FindEffective(currency string, at time.Time) (entities.ExchangeRate, error)
+	FindAll() ([]entities.ExchangeRate, error)
+}
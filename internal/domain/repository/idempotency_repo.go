@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type IdempotencyRepository interface {
+	Get(userID uint64, key string) (*entities.IdempotentResponse, error)
+	Lock(userID uint64, key, requestHash string) (bool, string, error)
+	Save(userID uint64, key string, response entities.IdempotentResponse) error
+	Unlock(userID uint64, key string) error
+}
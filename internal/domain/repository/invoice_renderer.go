@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+// InvoiceRenderer формирует печатную форму счета
+type InvoiceRenderer interface {
+	Render(invoice entities.Invoice) ([]byte, error)
+	ContentType() string
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type InvoiceRepository interface {
+	Save(invoice entities.Invoice) (entities.Invoice, error)
+	FindByID(id uint64) (entities.Invoice, error)
+	FindByReference(storeID uint64, reference string) (entities.Invoice, error)
+	FindAllByStore(storeID uint64) ([]entities.Invoice, error)
+}
@@ -4,10 +4,13 @@ import (
 	"github.com/labstack/echo/v4"
 	"marketplace/internal/domain/entities"
 	"marketplace/internal/domain/enums"
+	"time"
 )
 
 type JWTRepository interface {
 	SaveToken(userID uint64, token *entities.TokenDetails, tokenType enums.Token, ctx echo.Context) error
 	GetToken(userID uint64, tokenType enums.Token, ctx echo.Context) (*entities.TokenDetails, error)
 	DeleteToken(userID uint64, tokenType enums.Token, ctx echo.Context) error
+	RevokeSessions(userID uint64) error
+	SessionsRevokedAt(userID uint64) (time.Time, error)
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type LedgerRepository interface {
+	Append(transaction entities.LedgerTransaction) (entities.LedgerTransaction, error)
+	FindAll() ([]entities.LedgerTransaction, error)
+	FindByStore(storeID uint64) ([]entities.LedgerTransaction, error)
+	FindByReference(reference string) ([]entities.LedgerTransaction, error)
+	SaveBatch(batch entities.PayoutBatch) (entities.PayoutBatch, error)
+	FindBatches() ([]entities.PayoutBatch, error)
+}
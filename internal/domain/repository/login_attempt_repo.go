@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"marketplace/internal/domain/enums"
+	"time"
+)
+
+type LoginAttemptRepository interface {
+	RegisterFailure(scope enums.LoginAttemptScope, key string) (int64, error)
+	Block(scope enums.LoginAttemptScope, key string, duration time.Duration, locked bool) error
+	BlockedFor(scope enums.LoginAttemptScope, key string) (time.Duration, bool, error)
+	Reset(scope enums.LoginAttemptScope, key string) error
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "marketplace/internal/domain/entities"
+
+// Mailer доставляет электронные письма (SMTP-сервер, файлы для разработки и т.д.)
+type Mailer interface {
+	Send(message entities.EmailMessage) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type NotificationPreferencesRepository interface {
+	Save(preferences entities.NotificationPreferences) error
+	FindByUser(userID uint64) (entities.NotificationPreferences, error)
+}
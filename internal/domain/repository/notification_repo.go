@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type NotificationRepository interface {
+	Save(notification entities.Notification) (entities.Notification, error)
+	FindByID(id uint64) (entities.Notification, error)
+	Update(notification entities.Notification) error
+	FindAllByUser(userID uint64) ([]entities.Notification, error)
+}
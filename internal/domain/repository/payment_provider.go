@@ -0,0 +1,11 @@
+package repository
+
+import "marketplace/internal/domain/entities"
+
+// PaymentProvider списывает и возвращает деньги покупателей через платежный сервис
+type PaymentProvider interface {
+	// Charge списывает сумму и возвращает идентификатор платежа у провайдера
+	Charge(charge entities.PaymentCharge) (string, error)
+	// Refund возвращает покупателю часть или всю сумму платежа
+	Refund(providerPaymentID string, amount float64) error
+}
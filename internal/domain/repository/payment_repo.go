@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type PaymentRepository interface {
+	// Save сохраняет оплату; счет можно оплатить только один раз
+	Save(payment entities.Payment) (entities.Payment, error)
+	FindByInvoice(invoiceID uint64) (entities.Payment, error)
+	FindAllByBuyer(buyerID uint64) ([]entities.Payment, error)
+	// AddRefund увеличивает возвращенную сумму, если она не превысит оплату
+	AddRefund(id uint64, amount float64) (entities.Payment, error)
+}
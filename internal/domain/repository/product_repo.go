@@ -8,6 +8,12 @@ type ProductRepository interface {
 	Save(product entities.Product) error
 	FindByID(id uint64) (entities.Product, error)
 	Update(product entities.Product) error
+	UpdateRating(id uint64, rating float64, reviewCount int) error
+	AdjustStock(id uint64, sku string, delta int) error
+	AppendImage(id uint64, image entities.ProductImage, limit int) error
+	RemoveImage(id uint64, imageID string) (entities.ProductImage, error)
 	Delete(id uint64) error
 	FindAllByStore(storeID uint64) ([]entities.Product, error)
+	FindBySKU(sku string) (entities.Product, error)
+	FindAllByCategories(categoryIDs []uint64) ([]entities.Product, error)
 }
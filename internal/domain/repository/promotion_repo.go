@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type PromotionRepository interface {
+	Save(promotion entities.Promotion) (entities.Promotion, error)
+	FindByID(id uint64) (entities.Promotion, error)
+	Delete(id uint64) error
+	FindAllByStore(storeID uint64) ([]entities.Promotion, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+)
+
+type QuestionRepository interface {
+	SaveQuestion(question entities.Question) (entities.Question, error)
+	FindQuestionByID(id uint64) (entities.Question, error)
+	UpdateQuestion(question entities.Question) error
+	FindQuestionsByProduct(productID uint64) ([]entities.Question, error)
+	FindQuestionsByStatus(status enums.ModerationStatus) ([]entities.Question, error)
+	SaveAnswer(answer entities.Answer) (entities.Answer, error)
+	FindAnswerByID(id uint64) (entities.Answer, error)
+	UpdateAnswer(answer entities.Answer) error
+	FindAnswersByQuestion(questionID uint64) ([]entities.Answer, error)
+	FindAnswersByStatus(status enums.ModerationStatus) ([]entities.Answer, error)
+	AddVote(answerID, userID uint64) error
+	RemoveVote(answerID, userID uint64) error
+	CountVotes(answerID uint64) (int, error)
+}
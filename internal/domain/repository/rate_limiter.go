@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+	"time"
+)
+
+// RateLimiter учитывает запросы по ключу в скользящем окне. Запрос, не уложившийся
+// в лимит, не занимает места в окне.
+type RateLimiter interface {
+	Allow(key string, limit int, window time.Duration) (entities.RateLimitResult, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+)
+
+type ReturnRepository interface {
+	Save(request entities.ReturnRequest, maxQuantity int) (entities.ReturnRequest, error)
+	FindByID(id uint64) (entities.ReturnRequest, error)
+	Transition(request entities.ReturnRequest, from enums.ReturnStatus) (entities.ReturnRequest, error)
+	AppendPhoto(id uint64, photo entities.ReturnPhoto, limit int) (entities.ReturnRequest, error)
+	FindAllByStore(storeID uint64) ([]entities.ReturnRequest, error)
+	FindAllByBuyer(buyerID uint64) ([]entities.ReturnRequest, error)
+}
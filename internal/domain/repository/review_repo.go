@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type ReviewRepository interface {
+	Save(review entities.Review) (entities.Review, error)
+	FindByID(id uint64) (entities.Review, error)
+	Update(review entities.Review) error
+	SetReply(id uint64, reply entities.ReviewReply) (entities.Review, error)
+	AppendPhoto(id uint64, url string, limit int) (entities.Review, error)
+	FindByUserAndProduct(userID, productID uint64) (entities.Review, error)
+	FindAllByProduct(productID uint64) ([]entities.Review, error)
+	FindAllByStore(storeID uint64) ([]entities.Review, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type ShippingProfileRepository interface {
+	Save(profile entities.ShippingProfile) error
+	FindByStore(storeID uint64) (entities.ShippingProfile, error)
+}
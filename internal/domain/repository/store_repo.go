@@ -8,6 +8,7 @@ type StoreRepository interface {
 	Save(store entities.Store) error
 	FindByID(id uint64) (entities.Store, error)
 	Update(store entities.Store) error
+	UpdateRating(id uint64, rating float64, reviewCount int) error
 	Delete(id uint64) error
 	FindAll() ([]entities.Store, error)
 }
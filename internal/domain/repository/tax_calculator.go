@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+// TaxCalculator рассчитывает налоги по позициям корзины.
+// Встроенная реализация использует таблицу ставок; ее можно заменить внешним сервисом.
+type TaxCalculator interface {
+	Calculate(destination entities.ShippingDestination, lines []entities.TaxableLine) ([]entities.TaxLine, error)
+}
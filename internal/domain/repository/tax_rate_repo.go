@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type TaxRateRepository interface {
+	Save(rate entities.TaxRate) (entities.TaxRate, error)
+	Delete(id uint64) error
+	FindAll() ([]entities.TaxRate, error)
+}
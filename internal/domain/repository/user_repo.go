@@ -5,7 +5,14 @@ import (
 )
 
 type UserRepository interface {
-	Create(user entities.User) error
+	Create(user entities.User) (entities.User, error)
 	FindByEmail(email string) (entities.User, error)
-	FindByID(email uint64) (entities.User, error)
+	FindByID(id uint64) (entities.User, error)
+	Update(user entities.User) error
+	// Методы ниже меняют только свои поля, чтобы параллельные изменения
+	// разных полей одного пользователя не затирали друг друга
+	SetPassword(id uint64, password string) error
+	SetEmailVerified(id uint64) error
+	SetAdmin(id uint64, isAdmin bool) error
+	SetTwoFactor(id uint64, state entities.TwoFactorState) error
 }
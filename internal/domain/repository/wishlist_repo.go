@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"marketplace/internal/domain/entities"
+)
+
+type WishlistRepository interface {
+	Save(wishlist entities.Wishlist) (entities.Wishlist, error)
+	FindByID(id uint64) (entities.Wishlist, error)
+	FindByShareToken(token string) (entities.Wishlist, error)
+	Update(wishlist entities.Wishlist) (entities.Wishlist, error)
+	Delete(id uint64) error
+	FindAllByUser(userID uint64) ([]entities.Wishlist, error)
+	FindAllByProduct(productID uint64) ([]entities.Wishlist, error)
+}
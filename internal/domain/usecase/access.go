@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+)
+
+// requireStoreOwner возвращает магазин, если пользователь является его владельцем
+func requireStoreOwner(storeRepo repository.StoreRepository, storeID, userID uint64) (entities.Store, error) {
+	store, err := storeRepo.FindByID(storeID)
+	if err != nil {
+		return entities.Store{}, err
+	}
+	if store.OwnerID != userID {
+		return entities.Store{}, ErrForbidden
+	}
+	return store, nil
+}
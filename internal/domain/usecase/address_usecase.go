@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// addressRules требования к адресу для конкретной страны
+type addressRules struct {
+	postcode       *regexp.Regexp // nil — индекс не проверяется
+	requireRegion  bool
+	postcodeFormat string // Пример формата для сообщения об ошибке
+}
+
+// countryAddressRules правила для стран, где работает маркетплейс; остальные страны
+// проверяются только по общим полям
+var countryAddressRules = map[string]addressRules{
+	"RU": {postcode: regexp.MustCompile(`^\d{6}$`), requireRegion: true, postcodeFormat: "123456"},
+	"BY": {postcode: regexp.MustCompile(`^\d{6}$`), requireRegion: true, postcodeFormat: "220000"},
+	"KZ": {postcode: regexp.MustCompile(`^(\d{6}|[A-Z]\d{2}[A-Z]\d[A-Z]\d)$`), requireRegion: true, postcodeFormat: "050000 or A10A0A0"},
+	"AM": {postcode: regexp.MustCompile(`^\d{4}$`), postcodeFormat: "0010"},
+	"KG": {postcode: regexp.MustCompile(`^\d{6}$`), postcodeFormat: "720000"},
+	"UZ": {postcode: regexp.MustCompile(`^\d{6}$`), postcodeFormat: "100000"},
+	"DE": {postcode: regexp.MustCompile(`^\d{5}$`), postcodeFormat: "10115"},
+	"US": {postcode: regexp.MustCompile(`^\d{5}(-\d{4})?$`), requireRegion: true, postcodeFormat: "12345 or 12345-6789"},
+}
+
+// AddressUseCase управляет адресной книгой покупателей
+type AddressUseCase struct {
+	addressRepo repository.AddressRepository
+	validator   *validator.Validate
+	mu          sync.Mutex // Сериализует изменение адресов по умолчанию
+}
+
+// NewAddressUseCase создает новый экземпляр AddressUseCase
+func NewAddressUseCase(addressRepo repository.AddressRepository, validate *validator.Validate) *AddressUseCase {
+	return &AddressUseCase{addressRepo: addressRepo, validator: validate}
+}
+
+// CreateAddress добавляет адрес пользователя; первый адрес становится адресом по умолчанию
+func (u *AddressUseCase) CreateAddress(userID uint64, address entities.Address) (entities.Address, error) {
+	address = normalizeAddress(address)
+	if err := u.ValidateAddress(address); err != nil {
+		return entities.Address{}, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	existing, err := u.addressRepo.FindAllByUser(userID)
+	if err != nil {
+		return entities.Address{}, err
+	}
+	if len(existing) == 0 {
+		address.IsDefaultBilling = true
+		address.IsDefaultShipping = true
+	}
+
+	address.UserID = userID
+	address, err = u.addressRepo.Save(address)
+	if err != nil {
+		return entities.Address{}, err
+	}
+	if err = u.resetOtherDefaults(address); err != nil {
+		return entities.Address{}, err
+	}
+	return address, nil
+}
+
+// UpdateAddress обновляет адрес пользователя
+func (u *AddressUseCase) UpdateAddress(userID uint64, address entities.Address) (entities.Address, error) {
+	address = normalizeAddress(address)
+	if err := u.ValidateAddress(address); err != nil {
+		return entities.Address{}, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, err := u.GetAddress(userID, address.ID); err != nil {
+		return entities.Address{}, err
+	}
+
+	address.UserID = userID
+	if err := u.addressRepo.Update(address); err != nil {
+		return entities.Address{}, err
+	}
+	if err := u.resetOtherDefaults(address); err != nil {
+		return entities.Address{}, err
+	}
+	return u.addressRepo.FindByID(address.ID)
+}
+
+// DeleteAddress удаляет адрес пользователя
+func (u *AddressUseCase) DeleteAddress(userID, addressID uint64) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, err := u.GetAddress(userID, addressID); err != nil {
+		return err
+	}
+	return u.addressRepo.Delete(addressID)
+}
+
+// GetAddress возвращает адрес, если он принадлежит пользователю
+func (u *AddressUseCase) GetAddress(userID, addressID uint64) (entities.Address, error) {
+	address, err := u.addressRepo.FindByID(addressID)
+	if err != nil {
+		return entities.Address{}, err
+	}
+	if address.UserID != userID {
+		// Чужой адрес неотличим от несуществующего
+		return entities.Address{}, fmt.Errorf("address not found")
+	}
+	return address, nil
+}
+
+// GetAddresses возвращает все адреса пользователя
+func (u *AddressUseCase) GetAddresses(userID uint64) ([]entities.Address, error) {
+	return u.addressRepo.FindAllByUser(userID)
+}
+
+// SnapshotAddress возвращает копию адреса пользователя для сохранения в документах,
+// например в заказе, чтобы последующие правки адресной книги не меняли историю
+func (u *AddressUseCase) SnapshotAddress(userID, addressID uint64) (entities.Address, error) {
+	address, err := u.GetAddress(userID, addressID)
+	if err != nil {
+		return entities.Address{}, err
+	}
+	return address.Snapshot(), nil
+}
+
+// ValidateAddress проверяет общие поля адреса и правила страны
+func (u *AddressUseCase) ValidateAddress(address entities.Address) error {
+	if err := u.validator.Struct(address); err != nil {
+		return err
+	}
+
+	rules, ok := countryAddressRules[address.Country]
+	if !ok {
+		return nil
+	}
+	if rules.requireRegion && address.Region == "" {
+		return fmt.Errorf("region is required for country %s", address.Country)
+	}
+	if rules.postcode != nil && !rules.postcode.MatchString(address.Postcode) {
+		return fmt.Errorf("postcode for country %s must look like %s", address.Country, rules.postcodeFormat)
+	}
+	return nil
+}
+
+// resetOtherDefaults снимает флаги по умолчанию с остальных адресов пользователя,
+// если они установлены у address
+func (u *AddressUseCase) resetOtherDefaults(address entities.Address) error {
+	if !address.IsDefaultBilling && !address.IsDefaultShipping {
+		return nil
+	}
+
+	addresses, err := u.addressRepo.FindAllByUser(address.UserID)
+	if err != nil {
+		return err
+	}
+	for _, other := range addresses {
+		if other.ID == address.ID {
+			continue
+		}
+		changed := false
+		if address.IsDefaultBilling && other.IsDefaultBilling {
+			other.IsDefaultBilling = false
+			changed = true
+		}
+		if address.IsDefaultShipping && other.IsDefaultShipping {
+			other.IsDefaultShipping = false
+			changed = true
+		}
+		if changed {
+			if err = u.addressRepo.Update(other); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeAddress приводит код страны и индекс к каноническому виду
+func normalizeAddress(address entities.Address) entities.Address {
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+	address.Postcode = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(address.Postcode), " ", ""))
+	address.Region = strings.TrimSpace(address.Region)
+	return address
+}
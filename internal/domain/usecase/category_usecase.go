@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"sort"
+)
+
+// CategoryUseCase управляет деревом категорий и выборкой продуктов по категориям
+type CategoryUseCase struct {
+	categoryRepo repository.CategoryRepository
+	productRepo  repository.ProductRepository
+	validator    *validator.Validate
+}
+
+// NewCategoryUseCase создает новый экземпляр CategoryUseCase
+func NewCategoryUseCase(
+	categoryRepo repository.CategoryRepository,
+	productRepo repository.ProductRepository,
+	validate *validator.Validate,
+) *CategoryUseCase {
+	return &CategoryUseCase{categoryRepo: categoryRepo, productRepo: productRepo, validator: validate}
+}
+
+// CreateCategory создает новую категорию
+func (u *CategoryUseCase) CreateCategory(category entities.Category) error {
+	if err := u.validateCategory(category); err != nil {
+		return err
+	}
+	category.Children = nil
+	return u.categoryRepo.Save(category)
+}
+
+// UpdateCategory обновляет категорию, в том числе ее положение в дереве
+func (u *CategoryUseCase) UpdateCategory(category entities.Category) error {
+	if err := u.validateCategory(category); err != nil {
+		return err
+	}
+
+	// Новый родитель не может быть самой категорией или ее потомком
+	descendants, err := u.descendantIDs(category.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range descendants {
+		if id == category.ParentID {
+			return errors.New("category cannot be moved under itself")
+		}
+	}
+
+	category.Children = nil
+	return u.categoryRepo.Update(category)
+}
+
+// DeleteCategory удаляет категорию без дочерних категорий и продуктов
+func (u *CategoryUseCase) DeleteCategory(id uint64) error {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if category.ParentID == id {
+			return errors.New("category has subcategories")
+		}
+	}
+
+	// Продукты не должны ссылаться на удаленную категорию
+	products, err := u.productRepo.FindAllByCategories([]uint64{id})
+	if err != nil {
+		return err
+	}
+	if len(products) > 0 {
+		return fmt.Errorf("category is used by %d products", len(products))
+	}
+	return u.categoryRepo.Delete(id)
+}
+
+// GetCategoryTree возвращает дерево категорий, упорядоченное по позиции
+func (u *CategoryUseCase) GetCategoryTree() ([]entities.Category, error) {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint64][]entities.Category)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+	return buildCategoryTree(children, 0), nil
+}
+
+// GetProductsBySlug возвращает продукты всех магазинов из категории и ее потомков
+func (u *CategoryUseCase) GetProductsBySlug(slug string) ([]entities.Product, error) {
+	category, err := u.categoryRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := u.descendantIDs(category.ID)
+	if err != nil {
+		return nil, err
+	}
+	return u.productRepo.FindAllByCategories(ids)
+}
+
+// validateCategory проверяет поля категории и существование родителя. Уникальность slug
+// проверяет репозиторий при записи.
+func (u *CategoryUseCase) validateCategory(category entities.Category) error {
+	if err := u.validator.Struct(category); err != nil {
+		return err
+	}
+
+	if category.ParentID != 0 {
+		if category.ParentID == category.ID {
+			return errors.New("category cannot be its own parent")
+		}
+		if _, err := u.categoryRepo.FindByID(category.ParentID); err != nil {
+			return fmt.Errorf("parent category %d not found", category.ParentID)
+		}
+	}
+	return nil
+}
+
+// descendantIDs возвращает ID категории и всех ее потомков
+func (u *CategoryUseCase) descendantIDs(id uint64) ([]uint64, error) {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint64][]uint64)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.ID)
+	}
+
+	ids := []uint64{id}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+	return ids, nil
+}
+
+// buildCategoryTree рекурсивно собирает поддерево категорий для указанного родителя
+func buildCategoryTree(children map[uint64][]entities.Category, parentID uint64) []entities.Category {
+	level := children[parentID]
+	sort.Slice(level, func(i, j int) bool {
+		if level[i].Position != level[j].Position {
+			return level[i].Position < level[j].Position
+		}
+		return level[i].Name < level[j].Name
+	})
+
+	tree := make([]entities.Category, 0, len(level))
+	for _, category := range level {
+		category.Children = buildCategoryTree(children, category.ID)
+		tree = append(tree, category)
+	}
+	return tree
+}
@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"sort"
+	"strings"
+	"time"
+)
+
+// errConversationNotFound возвращается и для чужих переписок, чтобы они были неотличимы от несуществующих
+var errConversationNotFound = errors.New("conversation not found")
+
+// allowedAttachmentTypes допустимые типы вложений: изображения и PDF
+var allowedAttachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// ConversationUseCase управляет перепиской покупателей с магазинами
+type ConversationUseCase struct {
+	conversationRepo    repository.ConversationRepository
+	storeRepo           repository.StoreRepository
+	productRepo         repository.ProductRepository
+	storage             repository.PrivateBlobStorage
+	eventUseCase        *EventUseCase
+	notificationUseCase *NotificationUseCase
+	validator           *validator.Validate
+}
+
+// NewConversationUseCase создает новый экземпляр ConversationUseCase
+func NewConversationUseCase(
+	conversationRepo repository.ConversationRepository,
+	storeRepo repository.StoreRepository,
+	productRepo repository.ProductRepository,
+	storage repository.PrivateBlobStorage,
+	eventUseCase *EventUseCase,
+	notificationUseCase *NotificationUseCase,
+	validate *validator.Validate,
+) *ConversationUseCase {
+	return &ConversationUseCase{
+		conversationRepo:    conversationRepo,
+		storeRepo:           storeRepo,
+		productRepo:         productRepo,
+		storage:             storage,
+		eventUseCase:        eventUseCase,
+		notificationUseCase: notificationUseCase,
+		validator:           validate,
+	}
+}
+
+// StartConversation отправляет магазину первое сообщение. Если переписка покупателя
+// с магазином о том же продукте уже есть, сообщение добавляется в нее.
+func (u *ConversationUseCase) StartConversation(userID uint64, request entities.StartConversationRequest) (entities.Conversation, error) {
+	if err := u.validator.Struct(request); err != nil {
+		return entities.Conversation{}, err
+	}
+
+	store, err := u.storeRepo.FindByID(request.StoreID)
+	if err != nil {
+		return entities.Conversation{}, err
+	}
+	if store.OwnerID == userID {
+		return entities.Conversation{}, errors.New("cannot start a conversation with own store")
+	}
+	if request.ProductID != 0 {
+		product, err := u.productRepo.FindByID(request.ProductID)
+		if err != nil {
+			return entities.Conversation{}, err
+		}
+		if product.StoreID != store.ID {
+			return entities.Conversation{}, errors.New("product does not belong to store")
+		}
+	}
+
+	conversation, err := u.conversationRepo.Find(store.ID, userID, request.ProductID)
+	if err != nil {
+		conversation, err = u.conversationRepo.Save(entities.Conversation{
+			StoreID:   store.ID,
+			BuyerID:   userID,
+			ProductID: request.ProductID,
+		})
+		if err != nil {
+			return entities.Conversation{}, err
+		}
+	}
+
+	message, err := u.conversationRepo.SaveMessage(entities.Message{
+		ConversationID: conversation.ID,
+		SenderID:       userID,
+		Text:           request.Text,
+	})
+	if err != nil {
+		return entities.Conversation{}, err
+	}
+	u.publishMessage(conversation, message)
+	return u.conversationFor(userID, conversation.ID)
+}
+
+// SendMessage отправляет текстовое сообщение в переписку
+func (u *ConversationUseCase) SendMessage(conversationID, userID uint64, message entities.Message) (entities.Message, error) {
+	if err := u.validator.Struct(message); err != nil {
+		return entities.Message{}, err
+	}
+	if message.Text == "" {
+		return entities.Message{}, errors.New("message text is required")
+	}
+	conversation, err := u.participantConversation(conversationID, userID)
+	if err != nil {
+		return entities.Message{}, err
+	}
+
+	message, err = u.conversationRepo.SaveMessage(entities.Message{
+		ConversationID: conversationID,
+		SenderID:       userID,
+		Text:           message.Text,
+	})
+	if err != nil {
+		return entities.Message{}, err
+	}
+	u.publishMessage(conversation, message)
+	return message, nil
+}
+
+// SendAttachment отправляет сообщение с файлом, сохраняя файл в закрытом хранилище.
+// Файл доступен участникам переписки через GetAttachment.
+func (u *ConversationUseCase) SendAttachment(conversationID, userID uint64, text string, data []byte) (entities.Message, error) {
+	if len(data) > constants.MaxMessageAttachmentSize {
+		return entities.Message{}, fmt.Errorf("attachment exceeds %d bytes", constants.MaxMessageAttachmentSize)
+	}
+	message := entities.Message{Text: text}
+	if err := u.validator.Struct(message); err != nil {
+		return entities.Message{}, err
+	}
+	contentType := mimetype.Detect(data).String()
+	extension, ok := allowedAttachmentTypes[contentType]
+	if !ok {
+		return entities.Message{}, fmt.Errorf("unsupported attachment type: %s", contentType)
+	}
+	conversation, err := u.participantConversation(conversationID, userID)
+	if err != nil {
+		return entities.Message{}, err
+	}
+
+	name := uuid.New().String() + extension
+	key := attachmentKey(conversationID, name)
+	if err := u.storage.Put(key, data, contentType); err != nil {
+		return entities.Message{}, err
+	}
+
+	message.ConversationID = conversationID
+	message.SenderID = userID
+	message.Attachments = []entities.MessageAttachment{{
+		Name:        name,
+		URL:         fmt.Sprintf("/conversations/%d/attachments/%s", conversationID, name),
+		ContentType: contentType,
+		Size:        len(data),
+	}}
+	message, err = u.conversationRepo.SaveMessage(message)
+	if err != nil {
+		_ = u.storage.Delete(key)
+		return entities.Message{}, err
+	}
+	u.publishMessage(conversation, message)
+	return message, nil
+}
+
+// GetAttachment возвращает содержимое и тип вложения переписки, если пользователь — ее участник
+func (u *ConversationUseCase) GetAttachment(conversationID, userID uint64, name string) ([]byte, string, error) {
+	if _, err := u.participantConversation(conversationID, userID); err != nil {
+		return nil, "", err
+	}
+	if !isAttachmentName(name) {
+		return nil, "", errors.New("attachment not found")
+	}
+	data, err := u.storage.Get(attachmentKey(conversationID, name))
+	if err != nil {
+		return nil, "", errors.New("attachment not found")
+	}
+	return data, mimetype.Detect(data).String(), nil
+}
+
+// isAttachmentName проверяет, что name может быть именем вложения: UUID с допустимым расширением
+func isAttachmentName(name string) bool {
+	id, extension, _ := strings.Cut(name, ".")
+	if _, err := uuid.Parse(id); err != nil {
+		return false
+	}
+	for _, allowed := range allowedAttachmentTypes {
+		if allowed == "."+extension {
+			return true
+		}
+	}
+	return false
+}
+
+// attachmentKey ключ вложения в хранилище
+func attachmentKey(conversationID uint64, name string) string {
+	return fmt.Sprintf("conversations/%d/%s", conversationID, name)
+}
+
+// GetConversations возвращает переписки пользователя как покупателя и как владельца магазинов,
+// с недавней активностью первыми
+func (u *ConversationUseCase) GetConversations(userID uint64, page, perPage int) (entities.Page[entities.Conversation], error) {
+	conversations, err := u.userConversations(userID)
+	if err != nil {
+		return entities.Page[entities.Conversation]{}, err
+	}
+	sort.SliceStable(conversations, func(i, j int) bool {
+		return conversations[i].LastMessageAt.After(conversations[j].LastMessageAt)
+	})
+	return entities.NewPage(conversations, page, perPage), nil
+}
+
+// GetUnreadCount возвращает общее количество непрочитанных пользователем сообщений
+func (u *ConversationUseCase) GetUnreadCount(userID uint64) (entities.UnreadCount, error) {
+	conversations, err := u.userConversations(userID)
+	if err != nil {
+		return entities.UnreadCount{}, err
+	}
+
+	var count entities.UnreadCount
+	for _, conversation := range conversations {
+		count.Unread += conversation.UnreadCount
+	}
+	return count, nil
+}
+
+// GetMessages возвращает страницу сообщений переписки, новые первыми
+func (u *ConversationUseCase) GetMessages(conversationID, userID uint64, page, perPage int) (entities.Page[entities.Message], error) {
+	if _, err := u.participantConversation(conversationID, userID); err != nil {
+		return entities.Page[entities.Message]{}, err
+	}
+
+	messages, err := u.conversationRepo.FindMessages(conversationID)
+	if err != nil {
+		return entities.Page[entities.Message]{}, err
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID > messages[j].ID })
+	return entities.NewPage(messages, page, perPage), nil
+}
+
+// MarkRead отмечает прочитанными все сообщения собеседника в переписке
+func (u *ConversationUseCase) MarkRead(conversationID, userID uint64) error {
+	if _, err := u.participantConversation(conversationID, userID); err != nil {
+		return err
+	}
+	return u.conversationRepo.MarkRead(conversationID, userID, time.Now())
+}
+
+// publishMessage отправляет новое сообщение в канал событий получателя и на его почту
+func (u *ConversationUseCase) publishMessage(conversation entities.Conversation, message entities.Message) {
+	recipientID := conversation.BuyerID
+	if message.SenderID == conversation.BuyerID {
+		store, err := u.storeRepo.FindByID(conversation.StoreID)
+		if err != nil {
+			return
+		}
+		recipientID = store.OwnerID
+	}
+	u.eventUseCase.Publish(entities.UserTopic(recipientID), enums.MessageCreatedEvent, message)
+	u.notificationUseCase.SendEmail(recipientID, enums.NewMessageEmail, message)
+}
+
+// participantConversation возвращает переписку, если пользователь — покупатель или владелец магазина
+func (u *ConversationUseCase) participantConversation(conversationID, userID uint64) (entities.Conversation, error) {
+	conversation, err := u.conversationRepo.FindByID(conversationID)
+	if err != nil {
+		return entities.Conversation{}, err
+	}
+	if conversation.BuyerID == userID {
+		return conversation, nil
+	}
+	if store, err := u.storeRepo.FindByID(conversation.StoreID); err == nil && store.OwnerID == userID {
+		return conversation, nil
+	}
+	return entities.Conversation{}, errConversationNotFound
+}
+
+// conversationFor возвращает переписку с количеством непрочитанных пользователем сообщений
+func (u *ConversationUseCase) conversationFor(userID, conversationID uint64) (entities.Conversation, error) {
+	conversation, err := u.conversationRepo.FindByID(conversationID)
+	if err != nil {
+		return entities.Conversation{}, err
+	}
+	return u.withUnreadCount(userID, conversation)
+}
+
+// userConversations возвращает все переписки пользователя с количеством непрочитанных сообщений
+func (u *ConversationUseCase) userConversations(userID uint64) ([]entities.Conversation, error) {
+	stores, err := u.storeRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	var storeIDs []uint64
+	for _, store := range stores {
+		if store.OwnerID == userID {
+			storeIDs = append(storeIDs, store.ID)
+		}
+	}
+
+	conversations, err := u.conversationRepo.FindAllByParticipant(userID, storeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range conversations {
+		if conversations[i], err = u.withUnreadCount(userID, conversations[i]); err != nil {
+			return nil, err
+		}
+	}
+	return conversations, nil
+}
+
+// withUnreadCount заполняет количество непрочитанных пользователем сообщений собеседника
+func (u *ConversationUseCase) withUnreadCount(userID uint64, conversation entities.Conversation) (entities.Conversation, error) {
+	messages, err := u.conversationRepo.FindMessages(conversation.ID)
+	if err != nil {
+		return entities.Conversation{}, err
+	}
+	conversation.UnreadCount = 0
+	for _, message := range messages {
+		if message.SenderID != userID && message.ReadAt == nil {
+			conversation.UnreadCount++
+		}
+	}
+	return conversation, nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"os"
+	"strings"
+	"time"
+)
+
+// CurrencyUseCase управляет курсами валют и пересчетом цен для отображения
+type CurrencyUseCase struct {
+	rateRepo  repository.ExchangeRateRepository
+	validator *validator.Validate
+}
+
+// NewCurrencyUseCase создает новый экземпляр CurrencyUseCase
+func NewCurrencyUseCase(rateRepo repository.ExchangeRateRepository, validate *validator.Validate) *CurrencyUseCase {
+	return &CurrencyUseCase{rateRepo: rateRepo, validator: validate}
+}
+
+// LoadFromFile загружает курсы валют из локального JSON-файла
+func (u *CurrencyUseCase) LoadFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read exchange rates file: %v", err)
+	}
+
+	var rates []entities.ExchangeRate
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return fmt.Errorf("failed to parse exchange rates file: %v", err)
+	}
+
+	for _, rate := range rates {
+		if err = u.AddRate(rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddRate добавляет курс валюты, действующий с указанной даты
+func (u *CurrencyUseCase) AddRate(rate entities.ExchangeRate) error {
+	rate.Currency = strings.ToUpper(rate.Currency)
+	if err := u.validator.Struct(rate); err != nil {
+		return err
+	}
+	if rate.Currency == constants.BaseCurrency {
+		return fmt.Errorf("rate for base currency %s cannot be set", constants.BaseCurrency)
+	}
+	return u.rateRepo.Save(rate)
+}
+
+// GetRates возвращает все известные курсы валют
+func (u *CurrencyUseCase) GetRates() ([]entities.ExchangeRate, error) {
+	return u.rateRepo.FindAll()
+}
+
+// RateAt возвращает курс валюты, действовавший в указанный момент.
+// Используется для фиксации курса при оформлении заказа.
+func (u *CurrencyUseCase) RateAt(currency string, at time.Time) (entities.ExchangeRate, error) {
+	currency = strings.ToUpper(currency)
+	if currency == constants.BaseCurrency {
+		return entities.ExchangeRate{Currency: currency, Rate: 1}, nil
+	}
+
+	rate, err := u.rateRepo.FindEffective(currency, at)
+	if err != nil {
+		return entities.ExchangeRate{}, fmt.Errorf("unsupported currency: %s", currency)
+	}
+	return rate, nil
+}
+
+// Convert пересчитывает сумму из базовой валюты в указанную по текущему курсу
+func (u *CurrencyUseCase) Convert(amount float64, currency string) (float64, error) {
+	rate, err := u.RateAt(currency, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return utils.RoundPrice(amount * rate.Rate), nil
+}
+
+// ConvertProduct возвращает копию продукта с ценой в указанной валюте
+func (u *CurrencyUseCase) ConvertProduct(product entities.Product, currency string) (entities.Product, error) {
+	if currency == "" {
+		currency = constants.BaseCurrency
+	}
+
+	price, err := u.Convert(product.Price, currency)
+	if err != nil {
+		return entities.Product{}, err
+	}
+	product.Price = price
+	product.Currency = strings.ToUpper(currency)
+
+	// Пересчитываем переопределенные цены вариантов, не изменяя исходный продукт
+	variants := make([]entities.ProductVariant, len(product.Variants))
+	for i, variant := range product.Variants {
+		if variant.Price != nil {
+			variantPrice, err := u.Convert(*variant.Price, currency)
+			if err != nil {
+				return entities.Product{}, err
+			}
+			variant.Price = &variantPrice
+		}
+		variants[i] = variant
+	}
+	if len(variants) > 0 {
+		product.Variants = variants
+	}
+	return product, nil
+}
+
+// ConvertProducts пересчитывает цены списка продуктов в указанную валюту
+func (u *CurrencyUseCase) ConvertProducts(products []entities.Product, currency string) ([]entities.Product, error) {
+	converted := make([]entities.Product, 0, len(products))
+	for _, product := range products {
+		p, err := u.ConvertProduct(product, currency)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, p)
+	}
+	return converted, nil
+}
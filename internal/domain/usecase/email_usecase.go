@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	htmltemplate "html/template"
+	"io/fs"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+// emailTemplates шаблоны, которые должны быть на каждом языке
+var emailTemplates = []enums.EmailTemplate{
+	enums.WelcomeEmail,
+	enums.EmailVerificationEmail,
+	enums.PasswordResetEmail,
+	enums.PasswordChangedEmail,
+	enums.AccountLockedEmail,
+	enums.NewMessageEmail,
+	enums.PriceDropEmail,
+	enums.BackInStockEmail,
+}
+
+// emailLocales языки, на которых отправляются письма
+var emailLocales = []enums.Locale{enums.RussianLocale, enums.EnglishLocale}
+
+// emailTemplate тема и текстовая версия письма задаются блоками subject и text,
+// HTML-версия — отдельным шаблоном с экранированием
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// EmailUseCase формирует письма по шаблонам и доставляет их с повторными попытками
+type EmailUseCase struct {
+	mailer    repository.Mailer
+	templates map[enums.Locale]map[enums.EmailTemplate]emailTemplate
+
+	deliveries sync.WaitGroup
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewEmailUseCase создает новый экземпляр EmailUseCase. Шаблоны загружаются сразу,
+// чтобы отсутствующий или ошибочный шаблон не дал запустить сервер.
+func NewEmailUseCase(mailer repository.Mailer, templateFS fs.FS) (*EmailUseCase, error) {
+	templates := make(map[enums.Locale]map[enums.EmailTemplate]emailTemplate, len(emailLocales))
+	for _, locale := range emailLocales {
+		templates[locale] = make(map[enums.EmailTemplate]emailTemplate, len(emailTemplates))
+		for _, name := range emailTemplates {
+			path := fmt.Sprintf("%s/%s", locale, name)
+			text, err := texttemplate.ParseFS(templateFS, path+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %v", path, err)
+			}
+			html, err := htmltemplate.ParseFS(templateFS, path+".html")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %v", path, err)
+			}
+			templates[locale][name] = emailTemplate{text: text, html: html}
+		}
+	}
+	return &EmailUseCase{mailer: mailer, templates: templates, stop: make(chan struct{})}, nil
+}
+
+// Send формирует письмо по шаблону на языке locale и отправляет его в фоне.
+// Ошибка возвращается только при формировании письма, сбои доставки логируются.
+func (u *EmailUseCase) Send(to string, locale enums.Locale, template enums.EmailTemplate, data interface{}) error {
+	message, err := u.render(to, locale, template, data)
+	if err != nil {
+		return err
+	}
+	u.deliveries.Add(1)
+	go func() {
+		defer u.deliveries.Done()
+		u.deliver(message, template)
+	}()
+	return nil
+}
+
+// Shutdown прекращает повторные попытки и ждет завершения начатых отправок,
+// но не дольше, чем позволяет ctx
+func (u *EmailUseCase) Shutdown(ctx context.Context) error {
+	u.stopOnce.Do(func() { close(u.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		u.deliveries.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("emails are still being sent: %v", ctx.Err())
+	}
+}
+
+// render заполняет шаблон письма; неизвестный язык заменяется русским
+func (u *EmailUseCase) render(to string, locale enums.Locale, template enums.EmailTemplate, data interface{}) (entities.EmailMessage, error) {
+	localized, ok := u.templates[locale]
+	if !ok {
+		localized = u.templates[enums.RussianLocale]
+	}
+	tmpl, ok := localized[template]
+	if !ok {
+		return entities.EmailMessage{}, fmt.Errorf("unknown email template: %s", template)
+	}
+
+	message := entities.EmailMessage{To: to}
+	var buf bytes.Buffer
+	for _, part := range []struct {
+		name   string
+		target *string
+	}{
+		{"subject", &message.Subject},
+		{"text", &message.Text},
+	} {
+		buf.Reset()
+		if err := tmpl.text.ExecuteTemplate(&buf, part.name, data); err != nil {
+			return entities.EmailMessage{}, fmt.Errorf("failed to render email %s: %v", template, err)
+		}
+		*part.target = buf.String()
+	}
+
+	buf.Reset()
+	if err := tmpl.html.Execute(&buf, data); err != nil {
+		return entities.EmailMessage{}, fmt.Errorf("failed to render email %s: %v", template, err)
+	}
+	message.HTML = buf.String()
+	return message, nil
+}
+
+// deliver отправляет письмо, повторяя попытки с растущей паузой до остановки сервера
+func (u *EmailUseCase) deliver(message entities.EmailMessage, template enums.EmailTemplate) {
+	delay := constants.EmailRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := u.mailer.Send(message)
+		if err == nil {
+			return
+		}
+		if attempt == constants.EmailSendAttempts {
+			logrus.Errorf("Failed to send %s email to %s after %d attempts: %v", template, message.To, attempt, err)
+			return
+		}
+		logrus.Warnf("Failed to send %s email to %s (attempt %d): %v", template, message.To, attempt, err)
+		select {
+		case <-time.After(delay):
+		case <-u.stop:
+			logrus.Errorf("Failed to send %s email to %s: server is shutting down", template, message.To)
+			return
+		}
+		delay *= 2
+	}
+}
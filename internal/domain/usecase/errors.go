@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrForbidden возвращается, когда пользователь не имеет прав на ресурс
+	ErrForbidden = errors.New("access denied")
+	// ErrEventsUnavailable возвращается, когда шина событий не отвечает
+	ErrEventsUnavailable = errors.New("events are temporarily unavailable")
+)
+
+// LoginBlockedError возвращается, когда вход временно запрещен после неудачных попыток
+type LoginBlockedError struct {
+	RetryAfter time.Duration // Через сколько можно повторить попытку
+	Locked     bool          // Учетная запись заблокирована, а не просто замедлена
+}
+
+func (e *LoginBlockedError) Error() string {
+	if e.Locked {
+		return "account is temporarily locked after too many failed login attempts"
+	}
+	return "too many failed login attempts, try again later"
+}
+
+// ValidationError возвращается, когда входные данные не прошли проверку
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
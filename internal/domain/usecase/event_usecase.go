@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"strconv"
+	"strings"
+)
+
+// EventUseCase публикует события для клиентов реального времени и собирает поток событий
+// топика с возобновлением после переподключения
+type EventUseCase struct {
+	eventBus  repository.EventBus
+	storeRepo repository.StoreRepository
+}
+
+// NewEventUseCase создает новый экземпляр EventUseCase
+func NewEventUseCase(eventBus repository.EventBus, storeRepo repository.StoreRepository) *EventUseCase {
+	return &EventUseCase{eventBus: eventBus, storeRepo: storeRepo}
+}
+
+// Publish отправляет событие в топик. Ошибка доставки только логируется:
+// событие вторично по отношению к уже сохраненным данным.
+func (u *EventUseCase) Publish(topic string, eventType enums.EventType, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logrus.Errorf("Failed to marshal %s event: %v", eventType, err)
+		return
+	}
+	if _, err = u.eventBus.Publish(entities.Event{Topic: topic, Type: eventType, Payload: data}); err != nil {
+		logrus.Errorf("Failed to publish %s event: %v", eventType, err)
+	}
+}
+
+// Stream возвращает события топика: сначала пропущенные после lastEventID, затем новые.
+// Канал закрывается при отмене ctx или при отключении отставшего подписчика.
+func (u *EventUseCase) Stream(ctx context.Context, topic, lastEventID string) (<-chan entities.Event, error) {
+	if lastEventID != "" && !isEventID(lastEventID) {
+		return nil, &ValidationError{Err: fmt.Errorf("invalid last event id: %s", lastEventID)}
+	}
+
+	// Подписываемся до чтения истории, чтобы не потерять события между ними
+	live, err := u.eventBus.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEventsUnavailable, err)
+	}
+
+	var missed []entities.Event
+	if lastEventID != "" {
+		if missed, err = u.eventBus.Replay(topic, lastEventID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEventsUnavailable, err)
+		}
+	}
+
+	events := make(chan entities.Event)
+	go func() {
+		defer close(events)
+
+		lastID := lastEventID
+		send := func(event entities.Event) bool {
+			select {
+			case events <- event:
+				lastID = event.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, event := range missed {
+			if !send(event) {
+				return
+			}
+		}
+		for event := range live {
+			// События, уже отданные из истории, повторно не отправляются
+			if lastID != "" && compareEventIDs(event.ID, lastID) <= 0 {
+				continue
+			}
+			if !send(event) {
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// StreamStoreEvents возвращает поток событий магазина; доступен только его владельцу
+func (u *EventUseCase) StreamStoreEvents(ctx context.Context, storeID, userID uint64, lastEventID string) (<-chan entities.Event, error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return nil, err
+	}
+	return u.Stream(ctx, entities.StoreTopic(storeID), lastEventID)
+}
+
+// compareEventIDs сравнивает идентификаторы событий вида «миллисекунды-номер»
+func compareEventIDs(a, b string) int {
+	aTime, aSeq := splitEventID(a)
+	bTime, bSeq := splitEventID(b)
+	if aTime != bTime {
+		return cmp.Compare(aTime, bTime)
+	}
+	return cmp.Compare(aSeq, bSeq)
+}
+
+// isEventID проверяет, что идентификатор имеет вид «миллисекунды-номер»
+func isEventID(id string) bool {
+	timePart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return false
+	}
+	if _, err := strconv.ParseUint(timePart, 10, 64); err != nil {
+		return false
+	}
+	_, err := strconv.ParseUint(seqPart, 10, 64)
+	return err == nil
+}
+
+func splitEventID(id string) (uint64, uint64) {
+	timePart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(timePart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"sort"
+)
+
+// errInvoiceNotFound возвращается и для чужих счетов, чтобы они были неотличимы от несуществующих
+var errInvoiceNotFound = errors.New("invoice not found")
+
+// InvoiceUseCase выставляет счета магазинов и отдает их печатную форму.
+// Счет после выставления не изменяется: PDF формируется по сохраненному счету
+// один раз и хранится в закрытом хранилище.
+type InvoiceUseCase struct {
+	invoiceRepo    repository.InvoiceRepository
+	storeRepo      repository.StoreRepository
+	userRepo       repository.UserRepository
+	pricingUseCase *PricingUseCase
+	renderer       repository.InvoiceRenderer
+	storage        repository.PrivateBlobStorage
+	validator      *validator.Validate
+}
+
+// NewInvoiceUseCase создает новый экземпляр InvoiceUseCase
+func NewInvoiceUseCase(
+	invoiceRepo repository.InvoiceRepository,
+	storeRepo repository.StoreRepository,
+	userRepo repository.UserRepository,
+	pricingUseCase *PricingUseCase,
+	renderer repository.InvoiceRenderer,
+	storage repository.PrivateBlobStorage,
+	validate *validator.Validate,
+) *InvoiceUseCase {
+	return &InvoiceUseCase{
+		invoiceRepo:    invoiceRepo,
+		storeRepo:      storeRepo,
+		userRepo:       userRepo,
+		pricingUseCase: pricingUseCase,
+		renderer:       renderer,
+		storage:        storage,
+		validator:      validate,
+	}
+}
+
+// IssueInvoice выставляет счет магазина по позициям заказа. Корзина рассчитывается
+// заново от имени покупателя, поэтому цены, скидки и налоги задает сервер, а не магазин.
+// Номер счета присваивается репозиторием подряд в пределах магазина.
+func (u *InvoiceUseCase) IssueInvoice(storeID, userID uint64, input entities.InvoiceInput) (entities.Invoice, error) {
+	if err := u.validator.Struct(input); err != nil {
+		return entities.Invoice{}, err
+	}
+	store, err := requireStoreOwner(u.storeRepo, storeID, userID)
+	if err != nil {
+		return entities.Invoice{}, err
+	}
+	if _, err = u.userRepo.FindByID(input.BuyerID); err != nil {
+		return entities.Invoice{}, fmt.Errorf("buyer %d not found", input.BuyerID)
+	}
+
+	cart, err := u.pricingUseCase.Quote(input.BuyerID, input.PricingRequest)
+	if err != nil {
+		return entities.Invoice{}, err
+	}
+	for _, line := range cart.Lines {
+		if line.StoreID != storeID {
+			return entities.Invoice{}, fmt.Errorf("product %d does not belong to store %d", line.ProductID, storeID)
+		}
+	}
+
+	invoice := storeInvoice(storeID, cart)
+	invoice.BuyerID = input.BuyerID
+	invoice.Reference = input.Reference
+	invoice.Seller = entities.InvoiceSeller{
+		StoreName:    store.Name,
+		LegalName:    store.LegalName,
+		TaxID:        store.TaxID,
+		LegalAddress: store.LegalAddress,
+	}
+
+	invoice, err = u.invoiceRepo.Save(invoice)
+	if err != nil {
+		return entities.Invoice{}, err
+	}
+	// Счет уже выставлен; если файл не сохранился, он будет сформирован при первом запросе
+	if _, err = u.storeDocument(invoice); err != nil {
+		logrus.Errorf("Failed to store invoice %s: %v", invoice.Number, err)
+	}
+	return invoice, nil
+}
+
+// GetInvoice возвращает счет покупателю или владельцу магазина
+func (u *InvoiceUseCase) GetInvoice(id, userID uint64) (entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.FindByID(id)
+	if err != nil {
+		return entities.Invoice{}, errInvoiceNotFound
+	}
+	if invoice.BuyerID == userID {
+		return invoice, nil
+	}
+	if store, err := u.storeRepo.FindByID(invoice.StoreID); err == nil && store.OwnerID == userID {
+		return invoice, nil
+	}
+	return entities.Invoice{}, errInvoiceNotFound
+}
+
+// GetInvoiceDocument возвращает печатную форму счета и ее тип содержимого
+func (u *InvoiceUseCase) GetInvoiceDocument(id, userID uint64) ([]byte, string, error) {
+	invoice, err := u.GetInvoice(id, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := u.storage.Get(invoiceKey(invoice))
+	if err != nil {
+		// Файл не был сохранен при выставлении счета
+		if data, err = u.storeDocument(invoice); err != nil {
+			return nil, "", err
+		}
+	}
+	return data, u.renderer.ContentType(), nil
+}
+
+// GetStoreInvoices возвращает счета магазина его владельцу, новые первыми
+func (u *InvoiceUseCase) GetStoreInvoices(storeID, userID uint64, page, perPage int) (entities.Page[entities.Invoice], error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return entities.Page[entities.Invoice]{}, err
+	}
+
+	invoices, err := u.invoiceRepo.FindAllByStore(storeID)
+	if err != nil {
+		return entities.Page[entities.Invoice]{}, err
+	}
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].ID > invoices[j].ID })
+	return entities.NewPage(invoices, page, perPage), nil
+}
+
+// storeDocument формирует печатную форму счета и сохраняет ее
+func (u *InvoiceUseCase) storeDocument(invoice entities.Invoice) ([]byte, error) {
+	data, err := u.renderer.Render(invoice)
+	if err != nil {
+		return nil, err
+	}
+	if err = u.storage.Put(invoiceKey(invoice), data, u.renderer.ContentType()); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// invoiceKey путь печатной формы счета в закрытом хранилище
+func invoiceKey(invoice entities.Invoice) string {
+	return fmt.Sprintf("invoices/%d/%s.pdf", invoice.StoreID, invoice.Number)
+}
+
+// storeInvoice отбирает из корзины позиции, скидки и налоги магазина и считает итоги
+// так же, как PricingUseCase.Quote. Номера позиций в налогах и акциях пересчитываются
+// относительно счета.
+func storeInvoice(storeID uint64, cart entities.PricedCart) entities.Invoice {
+	invoice := entities.Invoice{
+		StoreID:   storeID,
+		Lines:     []entities.PricedLine{},
+		Discounts: []entities.AppliedDiscount{},
+		TaxLines:  []entities.TaxLine{},
+		Currency:  cart.Currency,
+	}
+	if invoice.Currency == "" {
+		invoice.Currency = constants.BaseCurrency
+	}
+
+	lineIndexes := make(map[int]int)
+	for i, line := range cart.Lines {
+		if line.StoreID != storeID {
+			continue
+		}
+		lineIndexes[i] = len(invoice.Lines)
+		invoice.Lines = append(invoice.Lines, line)
+		invoice.Subtotal += line.Subtotal
+		invoice.DiscountTotal += line.Discount
+	}
+
+	for _, discount := range cart.Discounts {
+		if discount.StoreID != storeID {
+			continue
+		}
+		if discount.LineIndex != nil {
+			index, ok := lineIndexes[*discount.LineIndex]
+			if !ok {
+				continue
+			}
+			discount.LineIndex = &index
+		}
+		invoice.Discounts = append(invoice.Discounts, discount)
+	}
+
+	var excludedTax float64
+	for _, taxLine := range cart.TaxLines {
+		index, ok := lineIndexes[taxLine.LineIndex]
+		if !ok {
+			continue
+		}
+		taxLine.LineIndex = index
+		invoice.TaxLines = append(invoice.TaxLines, taxLine)
+		invoice.TaxTotal += taxLine.Amount
+		if !taxLine.Included {
+			excludedTax += taxLine.Amount
+		}
+	}
+
+	invoice.Subtotal = utils.RoundPrice(invoice.Subtotal)
+	invoice.DiscountTotal = utils.RoundPrice(invoice.DiscountTotal)
+	invoice.TaxTotal = utils.RoundPrice(invoice.TaxTotal)
+	invoice.Total = utils.RoundPrice(invoice.Subtotal - invoice.DiscountTotal + excludedTax)
+	return invoice
+}
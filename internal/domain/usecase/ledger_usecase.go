@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LedgerUseCase ведет журнал расчетов с продавцами по принципу двойной записи.
+//
+// Оплата покупателя зачисляется на счет маркетплейса, из нее удерживается комиссия,
+// остаток становится задолженностью перед магазином и уходит ему пакетной выплатой.
+// Записи журнала не изменяются: ошибки исправляются только новыми движениями.
+type LedgerUseCase struct {
+	ledgerRepo   repository.LedgerRepository
+	ruleRepo     repository.CommissionRuleRepository
+	storeRepo    repository.StoreRepository
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	validator    *validator.Validate
+	// mu упорядочивает проверку остатков и запись движений
+	mu sync.Mutex
+}
+
+// NewLedgerUseCase создает новый экземпляр LedgerUseCase
+func NewLedgerUseCase(
+	ledgerRepo repository.LedgerRepository,
+	ruleRepo repository.CommissionRuleRepository,
+	storeRepo repository.StoreRepository,
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	validate *validator.Validate,
+) *LedgerUseCase {
+	return &LedgerUseCase{
+		ledgerRepo:   ledgerRepo,
+		ruleRepo:     ruleRepo,
+		storeRepo:    storeRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		validator:    validate,
+	}
+}
+
+// CreateCommissionRule задает ставку комиссии для магазина или категории
+func (u *LedgerUseCase) CreateCommissionRule(rule entities.CommissionRule) (entities.CommissionRule, error) {
+	if err := u.validator.Struct(rule); err != nil {
+		return entities.CommissionRule{}, err
+	}
+	if rule.StoreID != 0 {
+		if _, err := u.storeRepo.FindByID(rule.StoreID); err != nil {
+			return entities.CommissionRule{}, err
+		}
+	}
+	if rule.CategoryID != 0 {
+		if _, err := u.categoryRepo.FindByID(rule.CategoryID); err != nil {
+			return entities.CommissionRule{}, err
+		}
+	}
+	return u.ruleRepo.Save(rule)
+}
+
+// DeleteCommissionRule удаляет правило комиссии; уже проведенные движения не пересчитываются
+func (u *LedgerUseCase) DeleteCommissionRule(id uint64) error {
+	return u.ruleRepo.Delete(id)
+}
+
+// GetCommissionRules возвращает все правила комиссии
+func (u *LedgerUseCase) GetCommissionRules() ([]entities.CommissionRule, error) {
+	return u.ruleRepo.FindAll()
+}
+
+// RecordPayment проводит оплату заказа: по каждому магазину корзины создается
+// движение с зачислением денег, удержанием комиссии и задолженностью перед магазином.
+// Вызывается PaymentUseCase после подтверждения оплаты платежным провайдером.
+func (u *LedgerUseCase) RecordPayment(record entities.PaymentRecord) ([]entities.LedgerTransaction, error) {
+	if err := u.validator.Struct(record); err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	existing, err := u.ledgerRepo.FindByReference(record.Reference)
+	if err != nil {
+		return nil, err
+	}
+	for _, transaction := range existing {
+		if transaction.Type == enums.PaymentTransaction {
+			return nil, fmt.Errorf("payment %s is already recorded", record.Reference)
+		}
+	}
+
+	rules, err := u.ruleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Налог, начисляемый сверх цены, покупатель платит вместе с позицией
+	excludedTax := make(map[int]float64)
+	for _, taxLine := range record.Cart.TaxLines {
+		if !taxLine.Included {
+			excludedTax[taxLine.LineIndex] += taxLine.Amount
+		}
+	}
+
+	gross := make(map[uint64]float64)
+	commission := make(map[uint64]float64)
+	var storeIDs []uint64
+	for i, line := range record.Cart.Lines {
+		if _, seen := gross[line.StoreID]; !seen {
+			storeIDs = append(storeIDs, line.StoreID)
+		}
+		rate, err := u.commissionRate(rules, line)
+		if err != nil {
+			return nil, err
+		}
+		gross[line.StoreID] += line.Total + excludedTax[i]
+		commission[line.StoreID] += line.Total * rate / 100
+	}
+	if len(storeIDs) == 0 {
+		return nil, errors.New("payment has no lines")
+	}
+
+	transactions := make([]entities.LedgerTransaction, 0, len(storeIDs))
+	for _, storeID := range storeIDs {
+		amount := utils.RoundPrice(gross[storeID])
+		fee := utils.RoundPrice(commission[storeID])
+		transaction, err := u.post(entities.LedgerTransaction{
+			Type:      enums.PaymentTransaction,
+			StoreID:   storeID,
+			Reference: record.Reference,
+			Postings: []entities.LedgerPosting{
+				{Account: entities.MarketplaceCashAccount, Debit: amount},
+				{Account: entities.StorePayableAccount, StoreID: storeID, Credit: utils.RoundPrice(amount - fee)},
+				{Account: entities.MarketplaceCommissionAccount, Credit: fee},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// RecordRefund проводит возврат покупателю части оплаты магазина.
+// Комиссия возвращается пропорционально сумме возврата.
+func (u *LedgerUseCase) RecordRefund(record entities.RefundRecord) (entities.LedgerTransaction, error) {
+	if err := u.validator.Struct(record); err != nil {
+		return entities.LedgerTransaction{}, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	transactions, err := u.ledgerRepo.FindByReference(record.Reference)
+	if err != nil {
+		return entities.LedgerTransaction{}, err
+	}
+
+	var paid, fee, refunded float64
+	for _, transaction := range transactions {
+		if transaction.StoreID != record.StoreID {
+			continue
+		}
+		for _, posting := range transaction.Postings {
+			switch {
+			case transaction.Type == enums.PaymentTransaction && posting.Account == entities.MarketplaceCashAccount:
+				paid += posting.Debit
+			case transaction.Type == enums.PaymentTransaction && posting.Account == entities.MarketplaceCommissionAccount:
+				fee += posting.Credit
+			case transaction.Type == enums.RefundTransaction && posting.Account == entities.MarketplaceCashAccount:
+				refunded += posting.Credit
+			}
+		}
+	}
+	if paid == 0 {
+		return entities.LedgerTransaction{}, fmt.Errorf("payment %s for store %d not found", record.Reference, record.StoreID)
+	}
+
+	amount := utils.RoundPrice(record.Amount)
+	if amount > utils.RoundPrice(paid-refunded) {
+		return entities.LedgerTransaction{}, fmt.Errorf("refund exceeds refundable amount %.2f", utils.RoundPrice(paid-refunded))
+	}
+
+	feeShare := utils.RoundPrice(fee * amount / paid)
+	return u.post(entities.LedgerTransaction{
+		Type:      enums.RefundTransaction,
+		StoreID:   record.StoreID,
+		Reference: record.Reference,
+		Postings: []entities.LedgerPosting{
+			{Account: entities.StorePayableAccount, StoreID: record.StoreID, Debit: utils.RoundPrice(amount - feeShare)},
+			{Account: entities.MarketplaceCommissionAccount, Debit: feeShare},
+			{Account: entities.MarketplaceCashAccount, Credit: amount},
+		},
+	})
+}
+
+// GetStoreBalance возвращает остаток расчетов с магазином его владельцу
+func (u *LedgerUseCase) GetStoreBalance(storeID, userID uint64) (entities.StoreBalance, error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return entities.StoreBalance{}, err
+	}
+
+	transactions, err := u.ledgerRepo.FindByStore(storeID)
+	if err != nil {
+		return entities.StoreBalance{}, err
+	}
+	return storeBalance(storeID, transactions), nil
+}
+
+// GetStoreTransactions возвращает движения по магазину его владельцу, новые первыми
+func (u *LedgerUseCase) GetStoreTransactions(storeID, userID uint64, page, perPage int) (entities.Page[entities.LedgerTransaction], error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return entities.Page[entities.LedgerTransaction]{}, err
+	}
+
+	transactions, err := u.ledgerRepo.FindByStore(storeID)
+	if err != nil {
+		return entities.Page[entities.LedgerTransaction]{}, err
+	}
+	sort.Slice(transactions, func(i, j int) bool { return transactions[i].ID > transactions[j].ID })
+	return entities.NewPage(transactions, page, perPage), nil
+}
+
+// CreatePayoutBatch формирует выплаты всем магазинам с положительным остатком.
+// Если платить некому, пакет не сохраняется и возвращается пустым.
+func (u *LedgerUseCase) CreatePayoutBatch() (entities.PayoutBatch, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	transactions, err := u.ledgerRepo.FindAll()
+	if err != nil {
+		return entities.PayoutBatch{}, err
+	}
+
+	balances := make(map[uint64]float64)
+	var storeIDs []uint64
+	for _, transaction := range transactions {
+		if _, seen := balances[transaction.StoreID]; !seen {
+			storeIDs = append(storeIDs, transaction.StoreID)
+		}
+		balances[transaction.StoreID] += transaction.Amount()
+	}
+
+	batch := entities.PayoutBatch{Payouts: []entities.Payout{}, Currency: constants.BaseCurrency}
+	for _, storeID := range storeIDs {
+		amount := utils.RoundPrice(balances[storeID])
+		if amount <= 0 {
+			continue
+		}
+		transaction, err := u.post(entities.LedgerTransaction{
+			Type:      enums.PayoutTransaction,
+			StoreID:   storeID,
+			Reference: fmt.Sprintf("payout-%d-%d", storeID, time.Now().Unix()),
+			Postings: []entities.LedgerPosting{
+				{Account: entities.StorePayableAccount, StoreID: storeID, Debit: amount},
+				{Account: entities.MarketplaceCashAccount, Credit: amount},
+			},
+		})
+		if err != nil {
+			return entities.PayoutBatch{}, err
+		}
+		batch.Payouts = append(batch.Payouts, entities.Payout{
+			StoreID:       storeID,
+			Amount:        amount,
+			TransactionID: transaction.ID,
+		})
+		batch.Total += amount
+	}
+	batch.Total = utils.RoundPrice(batch.Total)
+	if len(batch.Payouts) == 0 {
+		return batch, nil
+	}
+
+	return u.ledgerRepo.SaveBatch(batch)
+}
+
+// GetPayoutBatches возвращает все сформированные пакеты выплат
+func (u *LedgerUseCase) GetPayoutBatches() ([]entities.PayoutBatch, error) {
+	return u.ledgerRepo.FindBatches()
+}
+
+// Reconcile сверяет журнал за период [from, to): баланс дебета и кредита каждой записи,
+// обороты по счетам и совпадение выплат с пакетами выплат
+func (u *LedgerUseCase) Reconcile(from, to time.Time) (entities.ReconciliationReport, error) {
+	transactions, err := u.ledgerRepo.FindAll()
+	if err != nil {
+		return entities.ReconciliationReport{}, err
+	}
+	batches, err := u.ledgerRepo.FindBatches()
+	if err != nil {
+		return entities.ReconciliationReport{}, err
+	}
+
+	report := entities.ReconciliationReport{
+		From:                     from,
+		To:                       to,
+		UnbalancedTransactionIDs: []uint64{},
+		Totals:                   make(map[enums.LedgerTransactionType]float64),
+		AccountBalances:          make(map[string]float64),
+	}
+	for _, transaction := range transactions {
+		if transaction.CreatedAt.Before(from) || !transaction.CreatedAt.Before(to) {
+			continue
+		}
+		report.TransactionCount++
+
+		var debit, credit float64
+		for _, posting := range transaction.Postings {
+			debit += posting.Debit
+			credit += posting.Credit
+			report.AccountBalances[posting.Account] = utils.RoundPrice(report.AccountBalances[posting.Account] + posting.Credit - posting.Debit)
+			if posting.Account == entities.MarketplaceCashAccount {
+				report.Totals[transaction.Type] = utils.RoundPrice(report.Totals[transaction.Type] + posting.Debit + posting.Credit)
+			}
+		}
+		if utils.RoundPrice(debit) != utils.RoundPrice(credit) {
+			report.UnbalancedTransactionIDs = append(report.UnbalancedTransactionIDs, transaction.ID)
+		}
+		report.DebitTotal += debit
+		report.CreditTotal += credit
+	}
+	for _, batch := range batches {
+		if !batch.CreatedAt.Before(from) && batch.CreatedAt.Before(to) {
+			report.PayoutBatchTotal += batch.Total
+		}
+	}
+
+	report.DebitTotal = utils.RoundPrice(report.DebitTotal)
+	report.CreditTotal = utils.RoundPrice(report.CreditTotal)
+	report.PayoutBatchTotal = utils.RoundPrice(report.PayoutBatchTotal)
+	report.Balanced = report.DebitTotal == report.CreditTotal && len(report.UnbalancedTransactionIDs) == 0
+	return report, nil
+}
+
+// post проверяет двойную запись и добавляет движение в журнал
+func (u *LedgerUseCase) post(transaction entities.LedgerTransaction) (entities.LedgerTransaction, error) {
+	postings := make([]entities.LedgerPosting, 0, len(transaction.Postings))
+	var debit, credit float64
+	for _, posting := range transaction.Postings {
+		if posting.Debit < 0 || posting.Credit < 0 {
+			return entities.LedgerTransaction{}, errors.New("ledger posting amounts cannot be negative")
+		}
+		// Нулевые проводки, например при нулевой комиссии, не записываются
+		if posting.Debit == 0 && posting.Credit == 0 {
+			continue
+		}
+		debit += posting.Debit
+		credit += posting.Credit
+		postings = append(postings, posting)
+	}
+	if utils.RoundPrice(debit) != utils.RoundPrice(credit) {
+		return entities.LedgerTransaction{}, fmt.Errorf("unbalanced ledger transaction: debit %.2f, credit %.2f", debit, credit)
+	}
+
+	transaction.Postings = postings
+	return u.ledgerRepo.Append(transaction)
+}
+
+// commissionRate возвращает ставку комиссии для позиции: правило магазина важнее
+// правила категории, а правило категории наследуется подкатегориями
+func (u *LedgerUseCase) commissionRate(rules []entities.CommissionRule, line entities.PricedLine) (float64, error) {
+	categoryRates := make(map[uint64]float64)
+	for _, rule := range rules {
+		if rule.StoreID != 0 && rule.StoreID == line.StoreID {
+			return rule.Rate, nil
+		}
+		if rule.CategoryID != 0 {
+			categoryRates[rule.CategoryID] = rule.Rate
+		}
+	}
+	if len(categoryRates) == 0 {
+		return constants.DefaultCommissionRate, nil
+	}
+
+	product, err := u.productRepo.FindByID(line.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	for _, categoryID := range product.CategoryIDs {
+		// Поднимаемся от категории продукта к корню до первого правила
+		for id := categoryID; id != 0; {
+			if rate, ok := categoryRates[id]; ok {
+				return rate, nil
+			}
+			category, err := u.categoryRepo.FindByID(id)
+			if err != nil {
+				break
+			}
+			id = category.ParentID
+		}
+	}
+	return constants.DefaultCommissionRate, nil
+}
+
+// storeBalance сворачивает движения магазина в остаток и обороты по видам
+func storeBalance(storeID uint64, transactions []entities.LedgerTransaction) entities.StoreBalance {
+	balance := entities.StoreBalance{StoreID: storeID, Currency: constants.BaseCurrency}
+	for _, transaction := range transactions {
+		amount := transaction.Amount()
+		balance.Balance += amount
+		switch transaction.Type {
+		case enums.PaymentTransaction:
+			for _, posting := range transaction.Postings {
+				switch posting.Account {
+				case entities.MarketplaceCashAccount:
+					balance.Paid += posting.Debit
+				case entities.MarketplaceCommissionAccount:
+					balance.Commission += posting.Credit - posting.Debit
+				}
+			}
+		case enums.RefundTransaction:
+			for _, posting := range transaction.Postings {
+				switch posting.Account {
+				case entities.MarketplaceCashAccount:
+					balance.Refunded += posting.Credit
+				case entities.MarketplaceCommissionAccount:
+					balance.Commission -= posting.Debit
+				}
+			}
+		case enums.PayoutTransaction:
+			balance.PaidOut -= amount
+		}
+	}
+	balance.Balance = utils.RoundPrice(balance.Balance)
+	balance.Paid = utils.RoundPrice(balance.Paid)
+	balance.Commission = utils.RoundPrice(balance.Commission)
+	balance.Refunded = utils.RoundPrice(balance.Refunded)
+	balance.PaidOut = utils.RoundPrice(balance.PaidOut)
+	return balance
+}
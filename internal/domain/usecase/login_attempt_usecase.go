@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"net"
+	"strings"
+	"time"
+)
+
+// LoginAttemptUseCase защищает вход от подбора пароля: считает неудачные попытки
+// по учетной записи и по IP-адресу, замедляет их и блокирует учетную запись.
+// Ошибки хранилища только записываются в журнал, чтобы сбой Redis не закрывал вход всем.
+type LoginAttemptUseCase struct {
+	loginAttemptRepo repository.LoginAttemptRepository
+}
+
+// NewLoginAttemptUseCase создает новый экземпляр LoginAttemptUseCase
+func NewLoginAttemptUseCase(loginAttemptRepo repository.LoginAttemptRepository) *LoginAttemptUseCase {
+	return &LoginAttemptUseCase{loginAttemptRepo: loginAttemptRepo}
+}
+
+// Check возвращает LoginBlockedError, если попытки входа в учетную запись или с адреса ip сейчас запрещены
+func (u *LoginAttemptUseCase) Check(email, ip string) error {
+	email = normalizeLoginEmail(email)
+	for _, target := range []struct {
+		scope enums.LoginAttemptScope
+		key   string
+	}{{enums.AccountScope, email}, {enums.IPScope, loginIPKey(ip)}} {
+		retryAfter, locked, err := u.loginAttemptRepo.BlockedFor(target.scope, target.key)
+		if err != nil {
+			logrus.Errorf("Failed to check login attempts: %v", err)
+			continue
+		}
+		if retryAfter > 0 {
+			logSecurityEvent(enums.LoginThrottledEvent, email, ip).
+				WithField("scope", target.scope).
+				Warn("Login attempt rejected")
+			return &LoginBlockedError{RetryAfter: retryAfter, Locked: locked}
+		}
+	}
+	return nil
+}
+
+// RegisterFailure учитывает неудачную попытку входа. Если после нее учетная запись
+// заблокирована, возвращается LoginBlockedError с Locked, иначе nil.
+func (u *LoginAttemptUseCase) RegisterFailure(email, ip string) error {
+	email = normalizeLoginEmail(email)
+	entry := logSecurityEvent(enums.LoginFailedEvent, email, ip)
+
+	accountFailures, err := u.loginAttemptRepo.RegisterFailure(enums.AccountScope, email)
+	if err != nil {
+		logrus.Errorf("Failed to register login failure: %v", err)
+	}
+	ipFailures, err := u.loginAttemptRepo.RegisterFailure(enums.IPScope, loginIPKey(ip))
+	if err != nil {
+		logrus.Errorf("Failed to register login failure: %v", err)
+	}
+	entry.WithFields(logrus.Fields{"account_failures": accountFailures, "ip_failures": ipFailures}).
+		Warn("Login failed")
+
+	if ipFailures >= constants.IPBackoffThreshold {
+		u.block(enums.IPScope, loginIPKey(ip), loginBackoff(ipFailures-constants.IPBackoffThreshold), false)
+	}
+	switch {
+	case accountFailures >= constants.AccountLockoutThreshold:
+		u.block(enums.AccountScope, email, constants.AccountLockoutDuration, true)
+		logSecurityEvent(enums.AccountLockedEvent, email, ip).
+			WithField("until", time.Now().Add(constants.AccountLockoutDuration)).
+			Warn("Account locked")
+		return &LoginBlockedError{RetryAfter: constants.AccountLockoutDuration, Locked: true}
+	case accountFailures >= constants.LoginBackoffThreshold:
+		u.block(enums.AccountScope, email, loginBackoff(accountFailures-constants.LoginBackoffThreshold), false)
+	}
+	return nil
+}
+
+// RegisterSuccess сбрасывает счетчик учетной записи после успешного входа. Счетчик
+// адреса не сбрасывается, иначе вход в свою учетную запись обнулял бы подбор чужих.
+func (u *LoginAttemptUseCase) RegisterSuccess(email, ip string) {
+	email = normalizeLoginEmail(email)
+	if err := u.loginAttemptRepo.Reset(enums.AccountScope, email); err != nil {
+		logrus.Errorf("Failed to reset login attempts: %v", err)
+	}
+	logSecurityEvent(enums.LoginSucceededEvent, email, ip).Info("Login succeeded")
+}
+
+// Unlock снимает блокировку учетной записи и сбрасывает ее счетчик
+func (u *LoginAttemptUseCase) Unlock(email string) error {
+	email = normalizeLoginEmail(email)
+	if err := u.loginAttemptRepo.Reset(enums.AccountScope, email); err != nil {
+		return err
+	}
+	logSecurityEvent(enums.AccountUnlockedEvent, email, "").Info("Account unlocked")
+	return nil
+}
+
+// block запрещает попытки входа, записывая ошибку хранилища в журнал
+func (u *LoginAttemptUseCase) block(scope enums.LoginAttemptScope, key string, duration time.Duration, locked bool) {
+	if err := u.loginAttemptRepo.Block(scope, key, duration, locked); err != nil {
+		logrus.Errorf("Failed to block login attempts: %v", err)
+	}
+}
+
+// loginBackoff задержка после excess неудач сверх порога: LoginBackoffBase, удваиваемая
+// с каждой следующей неудачей, но не больше LoginBackoffMax
+func loginBackoff(excess int64) time.Duration {
+	delay := constants.LoginBackoffBase
+	for i := int64(0); i < excess && delay < constants.LoginBackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > constants.LoginBackoffMax {
+		return constants.LoginBackoffMax
+	}
+	return delay
+}
+
+// normalizeLoginEmail приводит email к виду, в котором по нему ведется счетчик, чтобы
+// смена регистра не давала новых попыток
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// loginIPKey возвращает ключ счетчика попыток для адреса ip. IPv6-адреса считаются
+// по подсети /64, которую клиент обычно получает целиком и может перебирать адреса в ней.
+// Адрес ip должен определяться IPExtractor сервера, а не заголовками запроса.
+func loginIPKey(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() != nil {
+		return ip
+	}
+	return parsed.Mask(net.CIDRMask(64, 128)).String() + "/64"
+}
+
+// logSecurityEvent возвращает запись журнала аудита для события безопасности
+func logSecurityEvent(event enums.SecurityEvent, email, ip string) *logrus.Entry {
+	return logrus.WithFields(logrus.Fields{
+		"security_event": event,
+		"email":          email,
+		"ip":             ip,
+	})
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+)
+
+// NotificationUseCase сохраняет уведомления пользователей, отдает их владельцам
+// и отправляет письма с учетом настроек пользователя
+type NotificationUseCase struct {
+	notificationRepo repository.NotificationRepository
+	preferencesRepo  repository.NotificationPreferencesRepository
+	userRepo         repository.UserRepository
+	eventUseCase     *EventUseCase
+	emailUseCase     *EmailUseCase
+	validator        *validator.Validate
+}
+
+// NewNotificationUseCase создает новый экземпляр NotificationUseCase
+func NewNotificationUseCase(
+	notificationRepo repository.NotificationRepository,
+	preferencesRepo repository.NotificationPreferencesRepository,
+	userRepo repository.UserRepository,
+	eventUseCase *EventUseCase,
+	emailUseCase *EmailUseCase,
+	validate *validator.Validate,
+) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationRepo: notificationRepo,
+		preferencesRepo:  preferencesRepo,
+		userRepo:         userRepo,
+		eventUseCase:     eventUseCase,
+		emailUseCase:     emailUseCase,
+		validator:        validate,
+	}
+}
+
+// notificationEvents события реального времени для видов уведомлений
+var notificationEvents = map[enums.NotificationType]enums.EventType{
+	enums.BackInStockNotification: enums.StockAlertEvent,
+	enums.PriceDropNotification:   enums.PriceDropEvent,
+}
+
+// notificationEmails письма для видов уведомлений
+var notificationEmails = map[enums.NotificationType]enums.EmailTemplate{
+	enums.BackInStockNotification: enums.BackInStockEmail,
+	enums.PriceDropNotification:   enums.PriceDropEmail,
+}
+
+// optionalEmails письма, которые пользователь может отключить в настройках.
+// Остальные касаются учетной записи и отправляются всегда.
+var optionalEmails = map[enums.EmailTemplate]func(entities.NotificationPreferences) bool{
+	enums.NewMessageEmail:  func(p entities.NotificationPreferences) bool { return p.MessageEmails },
+	enums.PriceDropEmail:   func(p entities.NotificationPreferences) bool { return p.WishlistEmails },
+	enums.BackInStockEmail: func(p entities.NotificationPreferences) bool { return p.WishlistEmails },
+}
+
+// emailData данные, доступные шаблонам писем
+type emailData struct {
+	Recipient entities.User
+	Data      interface{}
+}
+
+// Notify сохраняет уведомление для пользователя, отправляет его в личный канал событий
+// и на почту
+func (u *NotificationUseCase) Notify(notification entities.Notification) error {
+	notification.Read = false
+	notification, err := u.notificationRepo.Save(notification)
+	if err != nil {
+		return err
+	}
+	if eventType, ok := notificationEvents[notification.Type]; ok {
+		u.eventUseCase.Publish(entities.UserTopic(notification.UserID), eventType, notification)
+	}
+	if template, ok := notificationEmails[notification.Type]; ok {
+		u.SendEmail(notification.UserID, template, notification)
+	}
+	return nil
+}
+
+// SendEmail отправляет пользователю письмо на выбранном им языке, если он не отключил
+// такие письма. Ошибки только логируются: письмо не должно срывать основное действие.
+func (u *NotificationUseCase) SendEmail(userID uint64, template enums.EmailTemplate, data interface{}) {
+	preferences, err := u.GetPreferences(userID)
+	if err != nil {
+		logrus.Errorf("Failed to load notification preferences of user %d: %v", userID, err)
+		return
+	}
+	if enabled, optional := optionalEmails[template]; optional && !enabled(preferences) {
+		return
+	}
+
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		logrus.Errorf("Failed to send %s email to user %d: %v", template, userID, err)
+		return
+	}
+	if err = u.emailUseCase.Send(user.Email, preferences.Locale, template, emailData{Recipient: user, Data: data}); err != nil {
+		logrus.Errorf("Failed to send %s email to user %d: %v", template, userID, err)
+	}
+}
+
+// GetPreferences возвращает настройки уведомлений пользователя или настройки по умолчанию
+func (u *NotificationUseCase) GetPreferences(userID uint64) (entities.NotificationPreferences, error) {
+	preferences, err := u.preferencesRepo.FindByUser(userID)
+	if err != nil {
+		return entities.DefaultNotificationPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences сохраняет настройки уведомлений пользователя
+func (u *NotificationUseCase) UpdatePreferences(userID uint64, preferences entities.NotificationPreferences) (entities.NotificationPreferences, error) {
+	if err := u.validator.Struct(preferences); err != nil {
+		return entities.NotificationPreferences{}, err
+	}
+	preferences.UserID = userID
+	if err := u.preferencesRepo.Save(preferences); err != nil {
+		return entities.NotificationPreferences{}, err
+	}
+	return preferences, nil
+}
+
+// GetNotifications возвращает уведомления пользователя, новые первыми
+func (u *NotificationUseCase) GetNotifications(userID uint64, page, perPage int) (entities.Page[entities.Notification], error) {
+	notifications, err := u.notificationRepo.FindAllByUser(userID)
+	if err != nil {
+		return entities.Page[entities.Notification]{}, err
+	}
+	return entities.NewPage(notifications, page, perPage), nil
+}
+
+// MarkRead отмечает уведомление пользователя прочитанным
+func (u *NotificationUseCase) MarkRead(userID, notificationID uint64) error {
+	notification, err := u.notificationRepo.FindByID(notificationID)
+	if err != nil {
+		return err
+	}
+	if notification.UserID != userID {
+		return errors.New("notification not found")
+	}
+	notification.Read = true
+	return u.notificationRepo.Update(notification)
+}
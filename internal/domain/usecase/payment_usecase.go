@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"sync"
+)
+
+// PaymentUseCase принимает оплату счетов через платежного провайдера и возвращает
+// деньги покупателям. Подтвержденная оплата и каждый возврат проводятся в журнале
+// расчетов с продавцами по номеру счета.
+type PaymentUseCase struct {
+	paymentRepo    repository.PaymentRepository
+	invoiceUseCase *InvoiceUseCase
+	ledgerUseCase  *LedgerUseCase
+	provider       repository.PaymentProvider
+	validator      *validator.Validate
+	// mu не дает оплатить счет дважды и вернуть больше оплаченного при параллельных запросах
+	mu sync.Mutex
+}
+
+// NewPaymentUseCase создает новый экземпляр PaymentUseCase
+func NewPaymentUseCase(
+	paymentRepo repository.PaymentRepository,
+	invoiceUseCase *InvoiceUseCase,
+	ledgerUseCase *LedgerUseCase,
+	provider repository.PaymentProvider,
+	validate *validator.Validate,
+) *PaymentUseCase {
+	return &PaymentUseCase{
+		paymentRepo:    paymentRepo,
+		invoiceUseCase: invoiceUseCase,
+		ledgerUseCase:  ledgerUseCase,
+		provider:       provider,
+		validator:      validate,
+	}
+}
+
+// PayInvoice списывает сумму счета у покупателя и проводит оплату в журнале
+func (u *PaymentUseCase) PayInvoice(invoiceID, userID uint64, input entities.PaymentInput) (entities.Payment, error) {
+	if err := u.validator.Struct(input); err != nil {
+		return entities.Payment{}, err
+	}
+	invoice, err := u.invoiceUseCase.GetInvoice(invoiceID, userID)
+	if err != nil {
+		return entities.Payment{}, err
+	}
+	if invoice.BuyerID != userID {
+		return entities.Payment{}, ErrForbidden
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, err = u.paymentRepo.FindByInvoice(invoice.ID); err == nil {
+		return entities.Payment{}, errors.New("invoice is already paid")
+	}
+	providerPaymentID, err := u.provider.Charge(entities.PaymentCharge{
+		Reference: invoice.Number,
+		Amount:    invoice.Total,
+		Currency:  invoice.Currency,
+		Token:     input.Token,
+	})
+	if err != nil {
+		return entities.Payment{}, err
+	}
+
+	payment, err := u.paymentRepo.Save(entities.Payment{
+		InvoiceID:         invoice.ID,
+		StoreID:           invoice.StoreID,
+		BuyerID:           invoice.BuyerID,
+		Reference:         invoice.Number,
+		Amount:            invoice.Total,
+		Currency:          invoice.Currency,
+		ProviderPaymentID: providerPaymentID,
+	})
+	if err != nil {
+		return entities.Payment{}, err
+	}
+	// Деньги уже списаны, поэтому ошибка журнала не отменяет оплату и разбирается по логу
+	if _, err = u.ledgerUseCase.RecordPayment(entities.PaymentRecord{
+		Reference: invoice.Number,
+		Cart:      invoiceCart(invoice),
+	}); err != nil {
+		logrus.Errorf("Failed to record payment %d for invoice %s: %v", payment.ID, invoice.Number, err)
+	}
+	return payment, nil
+}
+
+// GetInvoicePayment возвращает оплату счета покупателю или владельцу магазина
+func (u *PaymentUseCase) GetInvoicePayment(invoiceID, userID uint64) (entities.Payment, error) {
+	invoice, err := u.invoiceUseCase.GetInvoice(invoiceID, userID)
+	if err != nil {
+		return entities.Payment{}, err
+	}
+	return u.paymentRepo.FindByInvoice(invoice.ID)
+}
+
+// RefundInvoice возвращает покупателю часть оплаты счета через провайдера и проводит
+// возврат в журнале. Права на возврат проверяет вызывающий.
+func (u *PaymentUseCase) RefundInvoice(invoiceID uint64, amount float64) (entities.Payment, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	payment, err := u.paymentRepo.FindByInvoice(invoiceID)
+	if err != nil {
+		return entities.Payment{}, fmt.Errorf("invoice %d is not paid", invoiceID)
+	}
+	amount = utils.RoundPrice(amount)
+	if refundable := utils.RoundPrice(payment.Amount - payment.Refunded); amount > refundable {
+		return entities.Payment{}, fmt.Errorf("refund exceeds refundable amount %.2f", refundable)
+	}
+
+	if err = u.provider.Refund(payment.ProviderPaymentID, amount); err != nil {
+		return entities.Payment{}, err
+	}
+	if payment, err = u.paymentRepo.AddRefund(payment.ID, amount); err != nil {
+		return entities.Payment{}, err
+	}
+	// Деньги уже возвращены, поэтому ошибка журнала разбирается по логу
+	if _, err = u.ledgerUseCase.RecordRefund(entities.RefundRecord{
+		Reference: payment.Reference,
+		StoreID:   payment.StoreID,
+		Amount:    amount,
+	}); err != nil {
+		logrus.Errorf("Failed to record refund of %.2f for payment %d: %v", amount, payment.ID, err)
+	}
+	return payment, nil
+}
+
+// invoiceCart восстанавливает из счета корзину для проведения оплаты
+func invoiceCart(invoice entities.Invoice) entities.PricedCart {
+	return entities.PricedCart{
+		Lines:         invoice.Lines,
+		Discounts:     invoice.Discounts,
+		TaxLines:      invoice.TaxLines,
+		Subtotal:      invoice.Subtotal,
+		DiscountTotal: invoice.DiscountTotal,
+		TaxTotal:      invoice.TaxTotal,
+		Total:         invoice.Total,
+		Currency:      invoice.Currency,
+	}
+}
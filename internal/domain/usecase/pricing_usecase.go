@@ -0,0 +1,466 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PricingUseCase рассчитывает стоимость корзины с учетом акций и промокодов.
+//
+// Правила применения скидок детерминированы:
+//  1. На каждую позицию применяется не более одной автоматической акции — самая выгодная;
+//     при равной выгоде побеждает больший Priority, затем меньший ID.
+//  2. Промокоды применяются после акций к сумме позиций своего магазина,
+//     не более одного промокода на магазин, в порядке возрастания ID магазина.
+//  3. Скидка по промокоду распределяется по позициям магазина пропорционально их сумме.
+//
+// Налоги рассчитываются от сумм позиций после всех скидок, если известен адрес покупателя.
+type PricingUseCase struct {
+	productRepo    repository.ProductRepository
+	storeRepo      repository.StoreRepository
+	categoryRepo   repository.CategoryRepository
+	couponRepo     repository.CouponRepository
+	promotionRepo  repository.PromotionRepository
+	taxCalculator  repository.TaxCalculator
+	addressUseCase *AddressUseCase
+	validator      *validator.Validate
+}
+
+// NewPricingUseCase создает новый экземпляр PricingUseCase
+func NewPricingUseCase(
+	productRepo repository.ProductRepository,
+	storeRepo repository.StoreRepository,
+	categoryRepo repository.CategoryRepository,
+	couponRepo repository.CouponRepository,
+	promotionRepo repository.PromotionRepository,
+	taxCalculator repository.TaxCalculator,
+	addressUseCase *AddressUseCase,
+	validate *validator.Validate,
+) *PricingUseCase {
+	return &PricingUseCase{
+		productRepo:    productRepo,
+		storeRepo:      storeRepo,
+		categoryRepo:   categoryRepo,
+		couponRepo:     couponRepo,
+		promotionRepo:  promotionRepo,
+		taxCalculator:  taxCalculator,
+		addressUseCase: addressUseCase,
+		validator:      validate,
+	}
+}
+
+// Quote рассчитывает стоимость корзины пользователя в базовой валюте
+func (u *PricingUseCase) Quote(userID uint64, request entities.PricingRequest) (entities.PricedCart, error) {
+	if err := u.validator.Struct(request); err != nil {
+		return entities.PricedCart{}, err
+	}
+	now := time.Now()
+
+	destination, err := u.resolveDestination(userID, request)
+	if err != nil {
+		return entities.PricedCart{}, err
+	}
+
+	cart := entities.PricedCart{
+		Lines:       make([]entities.PricedLine, 0, len(request.Lines)),
+		Discounts:   []entities.AppliedDiscount{},
+		TaxLines:    []entities.TaxLine{},
+		Currency:    constants.BaseCurrency,
+		Destination: destination,
+	}
+	products := make([]entities.Product, 0, len(request.Lines))
+	for _, line := range request.Lines {
+		product, pricedLine, err := u.priceLine(line)
+		if err != nil {
+			return entities.PricedCart{}, err
+		}
+		products = append(products, product)
+		cart.Lines = append(cart.Lines, pricedLine)
+	}
+
+	if err := u.applyPromotions(&cart, products, now); err != nil {
+		return entities.PricedCart{}, err
+	}
+	if err := u.applyCoupons(&cart, userID, request.CouponCodes, now); err != nil {
+		return entities.PricedCart{}, err
+	}
+	if err := u.applyTaxes(&cart); err != nil {
+		return entities.PricedCart{}, err
+	}
+
+	var excludedTax float64
+	for _, line := range cart.Lines {
+		cart.Subtotal += line.Subtotal
+		cart.DiscountTotal += line.Discount
+	}
+	for _, taxLine := range cart.TaxLines {
+		cart.TaxTotal += taxLine.Amount
+		if !taxLine.Included {
+			excludedTax += taxLine.Amount
+		}
+	}
+	cart.Subtotal = utils.RoundPrice(cart.Subtotal)
+	cart.DiscountTotal = utils.RoundPrice(cart.DiscountTotal)
+	cart.TaxTotal = utils.RoundPrice(cart.TaxTotal)
+	cart.Total = utils.RoundPrice(cart.Subtotal - cart.DiscountTotal + excludedTax)
+	return cart, nil
+}
+
+// RedeemCoupons фиксирует использование промокодов из рассчитанной корзины.
+// Лимиты проверяются и расходуются атомарно для всех промокодов корзины; Quote
+// их только проверяет. Должен вызываться при оформлении заказа. Оформления заказа
+// пока нет, метод нигде не вызывается, поэтому лимиты использования не соблюдаются.
+func (u *PricingUseCase) RedeemCoupons(userID uint64, cart entities.PricedCart) error {
+	now := time.Now()
+	var redemptions []entities.CouponRedemption
+	for _, discount := range cart.Discounts {
+		if discount.Source != entities.CouponDiscountSource {
+			continue
+		}
+		// Промокод мог истечь или быть удален после расчета корзины
+		coupon, err := u.couponRepo.FindByID(discount.ID)
+		if err != nil {
+			return fmt.Errorf("coupon %s not found", discount.Code)
+		}
+		if !coupon.IsActive(now) {
+			return fmt.Errorf("coupon %s is not active", coupon.Code)
+		}
+		redemptions = append(redemptions, entities.CouponRedemption{
+			CouponID:   coupon.ID,
+			UserID:     userID,
+			RedeemedAt: now,
+		})
+	}
+	if len(redemptions) == 0 {
+		return nil
+	}
+	return u.couponRepo.SaveRedemptions(redemptions)
+}
+
+// priceLine находит продукт позиции и рассчитывает ее цену без скидок
+func (u *PricingUseCase) priceLine(line entities.CartLine) (entities.Product, entities.PricedLine, error) {
+	product, err := u.productRepo.FindByID(line.ProductID)
+	if err != nil {
+		return entities.Product{}, entities.PricedLine{}, fmt.Errorf("product %d not found", line.ProductID)
+	}
+
+	unitPrice := product.Price
+	if len(product.Variants) > 0 {
+		variant, ok := product.FindVariant(line.SKU)
+		if !ok {
+			return entities.Product{}, entities.PricedLine{}, fmt.Errorf("product %d requires a valid variant sku", product.ID)
+		}
+		unitPrice = variant.EffectivePrice(product)
+	} else if line.SKU != "" {
+		return entities.Product{}, entities.PricedLine{}, fmt.Errorf("product %d has no variants", product.ID)
+	}
+
+	taxClass := product.TaxClass
+	if taxClass == "" {
+		taxClass = entities.StandardTaxClass
+	}
+
+	subtotal := utils.RoundPrice(unitPrice * float64(line.Quantity))
+	return product, entities.PricedLine{
+		ProductID: product.ID,
+		SKU:       line.SKU,
+		StoreID:   product.StoreID,
+		Name:      product.Name,
+		Quantity:  line.Quantity,
+		UnitPrice: unitPrice,
+		Subtotal:  subtotal,
+		Total:     subtotal,
+		TaxClass:  taxClass,
+	}, nil
+}
+
+// resolveDestination возвращает адрес покупателя из адресной книги или из запроса
+func (u *PricingUseCase) resolveDestination(userID uint64, request entities.PricingRequest) (*entities.ShippingDestination, error) {
+	if request.AddressID != 0 {
+		address, err := u.addressUseCase.GetAddress(userID, request.AddressID)
+		if err != nil {
+			return nil, err
+		}
+		destination := address.Destination()
+		return &destination, nil
+	}
+	if request.Destination == nil {
+		return nil, nil
+	}
+	if err := u.validator.Struct(request.Destination); err != nil {
+		return nil, err
+	}
+	return request.Destination, nil
+}
+
+// applyTaxes рассчитывает налоги по позициям через TaxCalculator с учетом того,
+// включен ли налог в цены магазина
+func (u *PricingUseCase) applyTaxes(cart *entities.PricedCart) error {
+	if cart.Destination == nil {
+		return nil
+	}
+
+	includesTax := make(map[uint64]bool)
+	taxableLines := make([]entities.TaxableLine, 0, len(cart.Lines))
+	for i, line := range cart.Lines {
+		included, known := includesTax[line.StoreID]
+		if !known {
+			if store, err := u.storeRepo.FindByID(line.StoreID); err == nil {
+				included = store.PricesIncludeTax
+			}
+			includesTax[line.StoreID] = included
+		}
+		taxableLines = append(taxableLines, entities.TaxableLine{
+			LineIndex:        i,
+			StoreID:          line.StoreID,
+			TaxClass:         line.TaxClass,
+			Amount:           line.Total,
+			PricesIncludeTax: included,
+		})
+	}
+
+	taxLines, err := u.taxCalculator.Calculate(*cart.Destination, taxableLines)
+	if err != nil {
+		return fmt.Errorf("failed to calculate taxes: %v", err)
+	}
+	for _, taxLine := range taxLines {
+		if taxLine.LineIndex < 0 || taxLine.LineIndex >= len(cart.Lines) {
+			return fmt.Errorf("tax calculator returned unknown line %d", taxLine.LineIndex)
+		}
+		cart.Lines[taxLine.LineIndex].Tax = utils.RoundPrice(cart.Lines[taxLine.LineIndex].Tax + taxLine.Amount)
+	}
+	cart.TaxLines = taxLines
+	return nil
+}
+
+// applyPromotions применяет к каждой позиции самую выгодную активную акцию ее магазина
+func (u *PricingUseCase) applyPromotions(cart *entities.PricedCart, products []entities.Product, now time.Time) error {
+	promotionsByStore := make(map[uint64][]entities.Promotion)
+	categoryScopes := make(map[uint64]map[uint64]bool)
+
+	for i := range cart.Lines {
+		line := &cart.Lines[i]
+		promotions, loaded := promotionsByStore[line.StoreID]
+		if !loaded {
+			var err error
+			if promotions, err = u.promotionRepo.FindAllByStore(line.StoreID); err != nil {
+				return err
+			}
+			promotionsByStore[line.StoreID] = promotions
+		}
+
+		var best *entities.Promotion
+		var bestAmount float64
+		for j := range promotions {
+			promotion := &promotions[j]
+			if !promotion.IsActive(now) {
+				continue
+			}
+			if promotion.CategoryID != 0 {
+				scope, ok := categoryScopes[promotion.CategoryID]
+				if !ok {
+					var err error
+					if scope, err = u.categoryScope(promotion.CategoryID); err != nil {
+						return err
+					}
+					categoryScopes[promotion.CategoryID] = scope
+				}
+				if !productInScope(products[i], scope) {
+					continue
+				}
+			}
+
+			amount := min(promotionAmount(*promotion, *line), line.Subtotal)
+			if amount <= 0 {
+				continue
+			}
+			if best == nil || amount > bestAmount ||
+				(amount == bestAmount && (promotion.Priority > best.Priority ||
+					(promotion.Priority == best.Priority && promotion.ID < best.ID))) {
+				best, bestAmount = promotion, amount
+			}
+		}
+
+		if best != nil {
+			lineIndex := i
+			line.Discount = bestAmount
+			line.Total = utils.RoundPrice(line.Subtotal - line.Discount)
+			cart.Discounts = append(cart.Discounts, entities.AppliedDiscount{
+				Source:    entities.PromotionDiscountSource,
+				ID:        best.ID,
+				Name:      best.Name,
+				StoreID:   best.StoreID,
+				LineIndex: &lineIndex,
+				Amount:    bestAmount,
+			})
+		}
+	}
+	return nil
+}
+
+// applyCoupons применяет промокоды к позициям их магазинов после акций
+func (u *PricingUseCase) applyCoupons(cart *entities.PricedCart, userID uint64, codes []string, now time.Time) error {
+	coupons := make(map[uint64]entities.Coupon)
+	for _, code := range codes {
+		coupon, err := u.couponRepo.FindByCode(strings.ToUpper(strings.TrimSpace(code)))
+		if err != nil {
+			return fmt.Errorf("coupon %s not found", code)
+		}
+		if existing, ok := coupons[coupon.StoreID]; ok {
+			if existing.ID == coupon.ID {
+				continue
+			}
+			return fmt.Errorf("only one coupon per store can be applied")
+		}
+		if err = u.checkCouponUsage(coupon, userID, now); err != nil {
+			return err
+		}
+		coupons[coupon.StoreID] = coupon
+	}
+
+	storeIDs := make([]uint64, 0, len(coupons))
+	for storeID := range coupons {
+		storeIDs = append(storeIDs, storeID)
+	}
+	sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
+
+	for _, storeID := range storeIDs {
+		coupon := coupons[storeID]
+
+		var storeLines []int
+		var storeTotal float64
+		for i, line := range cart.Lines {
+			if line.StoreID == storeID {
+				storeLines = append(storeLines, i)
+				storeTotal += line.Total
+			}
+		}
+		storeTotal = utils.RoundPrice(storeTotal)
+		if len(storeLines) == 0 {
+			return fmt.Errorf("coupon %s does not apply to any item in the cart", coupon.Code)
+		}
+		if storeTotal < coupon.MinOrderValue {
+			return fmt.Errorf("coupon %s requires a minimum order of %.2f", coupon.Code, coupon.MinOrderValue)
+		}
+
+		var amount float64
+		switch coupon.Type {
+		case enums.PercentageDiscount:
+			amount = utils.RoundPrice(storeTotal * coupon.Value / 100)
+		case enums.FixedDiscount:
+			amount = min(coupon.Value, storeTotal)
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		distributeDiscount(cart.Lines, storeLines, storeTotal, amount)
+		cart.Discounts = append(cart.Discounts, entities.AppliedDiscount{
+			Source:  entities.CouponDiscountSource,
+			ID:      coupon.ID,
+			Code:    coupon.Code,
+			StoreID: coupon.StoreID,
+			Amount:  amount,
+		})
+	}
+	return nil
+}
+
+// checkCouponUsage проверяет срок действия и лимиты использования промокода
+func (u *PricingUseCase) checkCouponUsage(coupon entities.Coupon, userID uint64, now time.Time) error {
+	if !coupon.IsActive(now) {
+		return fmt.Errorf("coupon %s is not active", coupon.Code)
+	}
+	if coupon.UsageLimit > 0 {
+		used, err := u.couponRepo.CountRedemptions(coupon.ID)
+		if err != nil {
+			return err
+		}
+		if used >= coupon.UsageLimit {
+			return fmt.Errorf("coupon %s usage limit reached", coupon.Code)
+		}
+	}
+	if coupon.UsageLimitPerUser > 0 {
+		used, err := u.couponRepo.CountUserRedemptions(coupon.ID, userID)
+		if err != nil {
+			return err
+		}
+		if used >= coupon.UsageLimitPerUser {
+			return errors.New("coupon " + coupon.Code + " was already used")
+		}
+	}
+	return nil
+}
+
+// categoryScope возвращает множество из категории и всех ее потомков
+func (u *PricingUseCase) categoryScope(categoryID uint64) (map[uint64]bool, error) {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	children := make(map[uint64][]uint64)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.ID)
+	}
+
+	scope := map[uint64]bool{categoryID: true}
+	queue := []uint64{categoryID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if !scope[child] {
+				scope[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return scope, nil
+}
+
+// productInScope проверяет, относится ли продукт к одной из категорий
+func productInScope(product entities.Product, scope map[uint64]bool) bool {
+	for _, categoryID := range product.CategoryIDs {
+		if scope[categoryID] {
+			return true
+		}
+	}
+	return false
+}
+
+// promotionAmount рассчитывает скидку акции для позиции
+func promotionAmount(promotion entities.Promotion, line entities.PricedLine) float64 {
+	switch promotion.Type {
+	case enums.PercentageOffPromotion:
+		return utils.RoundPrice(line.Subtotal * promotion.Percent / 100)
+	case enums.BuyXGetYPromotion:
+		groups := line.Quantity / (promotion.BuyQuantity + promotion.FreeQuantity)
+		return utils.RoundPrice(float64(groups*promotion.FreeQuantity) * line.UnitPrice)
+	}
+	return 0
+}
+
+// distributeDiscount распределяет скидку по позициям пропорционально их сумме;
+// остаток от округления относится на последнюю позицию
+func distributeDiscount(lines []entities.PricedLine, indexes []int, total, amount float64) {
+	remaining := amount
+	for n, i := range indexes {
+		share := remaining
+		if n < len(indexes)-1 && total > 0 {
+			share = utils.RoundPrice(amount * lines[i].Total / total)
+		}
+		share = min(share, lines[i].Total)
+		lines[i].Discount = utils.RoundPrice(lines[i].Discount + share)
+		lines[i].Total = utils.RoundPrice(lines[i].Subtotal - lines[i].Discount)
+		remaining = utils.RoundPrice(remaining - share)
+	}
+}
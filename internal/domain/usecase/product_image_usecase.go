@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"image"
+	_ "image/gif"  // Регистрация декодера GIF
+	_ "image/jpeg" // Регистрация декодера JPEG
+	_ "image/png"  // Регистрация декодера PNG
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"time"
+
+	_ "golang.org/x/image/webp" // Регистрация декодера WebP
+)
+
+// allowedImageTypes допустимые типы изображений и расширения файлов для них
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ProductImageUseCase управляет изображениями продуктов
+type ProductImageUseCase struct {
+	productRepo repository.ProductRepository
+	storage     repository.BlobStorage
+}
+
+// NewProductImageUseCase создает новый экземпляр ProductImageUseCase
+func NewProductImageUseCase(productRepo repository.ProductRepository, storage repository.BlobStorage) *ProductImageUseCase {
+	return &ProductImageUseCase{productRepo: productRepo, storage: storage}
+}
+
+// AddImage проверяет и сохраняет изображение продукта вместе с миниатюрами
+func (u *ProductImageUseCase) AddImage(productID, userID uint64, data []byte) (entities.ProductImage, error) {
+	if len(data) > constants.MaxProductImageSize {
+		return entities.ProductImage{}, fmt.Errorf("image exceeds %d bytes", constants.MaxProductImageSize)
+	}
+
+	// Права проверяются до разбора изображения, чтобы чужие загрузки не тратили память и процессор
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil {
+		return entities.ProductImage{}, err
+	}
+	if product.OwnerID != userID {
+		return entities.ProductImage{}, ErrForbidden
+	}
+	if len(product.Images) >= constants.MaxProductImagesCount {
+		return entities.ProductImage{}, fmt.Errorf("product already has %d images", constants.MaxProductImagesCount)
+	}
+
+	// Тип определяется по содержимому, а не по заголовкам запроса
+	contentType := mimetype.Detect(data).String()
+	extension, ok := allowedImageTypes[contentType]
+	if !ok {
+		return entities.ProductImage{}, fmt.Errorf("unsupported image type: %s", contentType)
+	}
+
+	// Размеры проверяются до декодирования, чтобы не выделять память под огромные изображения.
+	// Память декодера растет с площадью, поэтому ограничено число пикселей, а не стороны.
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return entities.ProductImage{}, fmt.Errorf("failed to decode image: %v", err)
+	}
+	if int64(config.Width)*int64(config.Height) > constants.MaxProductImagePixels {
+		return entities.ProductImage{}, fmt.Errorf("image exceeds %d pixels", constants.MaxProductImagePixels)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return entities.ProductImage{}, fmt.Errorf("failed to decode image: %v", err)
+	}
+
+	img := entities.ProductImage{
+		ID:          uuid.New().String(),
+		ContentType: contentType,
+		Thumbnails:  make(map[string]string, len(constants.ThumbnailSizes)),
+		CreatedAt:   time.Now(),
+	}
+	prefix := fmt.Sprintf("products/%d/%s", productID, img.ID)
+
+	originalKey := prefix + "/original" + extension
+	if err = u.storage.Put(originalKey, data, contentType); err != nil {
+		return entities.ProductImage{}, err
+	}
+	img.URL = u.storage.URL(originalKey)
+
+	// Миниатюры PNG остаются в PNG ради прозрачности, остальные кодируются в JPEG
+	asPNG := contentType == "image/png"
+	thumbnailType, thumbnailExtension := "image/jpeg", ".jpg"
+	if asPNG {
+		thumbnailType, thumbnailExtension = "image/png", ".png"
+	}
+	for name, size := range constants.ThumbnailSizes {
+		thumbnail, err := utils.EncodeImage(utils.ResizeToFit(src, size), asPNG)
+		if err != nil {
+			u.deleteImageFiles(prefix, extension, thumbnailExtension)
+			return entities.ProductImage{}, fmt.Errorf("failed to encode thumbnail: %v", err)
+		}
+		key := prefix + "/" + name + thumbnailExtension
+		if err = u.storage.Put(key, thumbnail, thumbnailType); err != nil {
+			u.deleteImageFiles(prefix, extension, thumbnailExtension)
+			return entities.ProductImage{}, err
+		}
+		img.Thumbnails[name] = u.storage.URL(key)
+	}
+
+	// Лимит проверяется повторно в репозитории: параллельные загрузки не должны его превысить
+	if err = u.productRepo.AppendImage(productID, img, constants.MaxProductImagesCount); err != nil {
+		u.deleteImageFiles(prefix, extension, thumbnailExtension)
+		return entities.ProductImage{}, err
+	}
+	return img, nil
+}
+
+// DeleteImage удаляет изображение продукта и его файлы
+func (u *ProductImageUseCase) DeleteImage(productID, userID uint64, imageID string) error {
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil {
+		return err
+	}
+	if product.OwnerID != userID {
+		return ErrForbidden
+	}
+
+	img, err := u.productRepo.RemoveImage(productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	thumbnailExtension := ".jpg"
+	if img.ContentType == "image/png" {
+		thumbnailExtension = ".png"
+	}
+	u.deleteImageFiles(
+		fmt.Sprintf("products/%d/%s", productID, img.ID),
+		allowedImageTypes[img.ContentType],
+		thumbnailExtension,
+	)
+	return nil
+}
+
+// deleteImageFiles удаляет оригинал и миниатюры изображения, игнорируя ошибки
+func (u *ProductImageUseCase) deleteImageFiles(prefix, extension, thumbnailExtension string) {
+	_ = u.storage.Delete(prefix + "/original" + extension)
+	for name := range constants.ThumbnailSizes {
+		_ = u.storage.Delete(prefix + "/" + name + thumbnailExtension)
+	}
+}
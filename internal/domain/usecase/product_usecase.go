@@ -1,23 +1,56 @@
 package usecase
 
 import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
 	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
 	"marketplace/internal/domain/repository"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // ProductUseCase реализует интерфейс ProductUseCase
 type ProductUseCase struct {
-	productRepo repository.ProductRepository
+	productRepo     repository.ProductRepository
+	categoryRepo    repository.CategoryRepository
+	wishlistUseCase *WishlistUseCase
+	eventUseCase    *EventUseCase
+	validator       *validator.Validate
 }
 
 // NewProductUseCase создает новый экземпляр ProductUseCase
-func NewProductUseCase(productRepo repository.ProductRepository) *ProductUseCase {
-	return &ProductUseCase{productRepo: productRepo}
+func NewProductUseCase(
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	wishlistUseCase *WishlistUseCase,
+	eventUseCase *EventUseCase,
+	validate *validator.Validate,
+) *ProductUseCase {
+	return &ProductUseCase{
+		productRepo:     productRepo,
+		categoryRepo:    categoryRepo,
+		wishlistUseCase: wishlistUseCase,
+		eventUseCase:    eventUseCase,
+		validator:       validate,
+	}
 }
 
 // CreateProduct создает новый продукт
 func (p *ProductUseCase) CreateProduct(product entities.Product) error {
-	return p.productRepo.Save(product)
+	if err := p.validateProduct(product); err != nil {
+		return err
+	}
+	product.Images = nil
+	product.Rating = 0
+	product.ReviewCount = 0
+	if err := p.productRepo.Save(product); err != nil {
+		return err
+	}
+	p.eventUseCase.Publish(entities.StoreTopic(product.StoreID), enums.ProductCreatedEvent, product)
+	return nil
 }
 
 // GetProductByID получает продукт по ID
@@ -27,15 +60,197 @@ func (p *ProductUseCase) GetProductByID(id uint64) (entities.Product, error) {
 
 // UpdateProduct обновляет существующий продукт
 func (p *ProductUseCase) UpdateProduct(product entities.Product) error {
-	return p.productRepo.Update(product)
+	if err := p.validateProduct(product); err != nil {
+		return err
+	}
+
+	existing, err := p.productRepo.FindByID(product.ID)
+	if err != nil {
+		return err
+	}
+
+	// Изображения и рейтинг изменяются через ProductImageUseCase и ReviewUseCase,
+	// репозиторий сохраняет текущие, поэтому событие строится по сохраненной записи
+	if err := p.productRepo.Update(product); err != nil {
+		return err
+	}
+	if stored, err := p.productRepo.FindByID(product.ID); err == nil {
+		product = stored
+	}
+	p.publishProductUpdated(existing, product)
+	// Снижение цены и поступление в продажу отслеживаются по спискам желаний.
+	// Продукт уже сохранен, поэтому ошибка уведомлений только логируется.
+	if err := p.wishlistUseCase.NotifyProductChanged(existing, product); err != nil {
+		logrus.Errorf("Failed to notify wishlists about product %d: %v", product.ID, err)
+	}
+	return nil
+}
+
+// Restock возвращает на остаток варианта quantity единиц, например после возврата товара.
+// Продукт должен принадлежать магазину storeID, иначе остаток не меняется.
+func (p *ProductUseCase) Restock(productID, storeID uint64, sku string, quantity int) error {
+	existing, err := p.productRepo.FindByID(productID)
+	if err != nil {
+		return err
+	}
+	if existing.StoreID != storeID {
+		return fmt.Errorf("product %d does not belong to store %d", productID, storeID)
+	}
+	if err = p.productRepo.AdjustStock(productID, sku, quantity); err != nil {
+		return err
+	}
+	product, err := p.productRepo.FindByID(productID)
+	if err != nil {
+		return err
+	}
+
+	p.publishProductUpdated(existing, product)
+	if err := p.wishlistUseCase.NotifyProductChanged(existing, product); err != nil {
+		logrus.Errorf("Failed to notify wishlists about product %d: %v", product.ID, err)
+	}
+	return nil
 }
 
 // DeleteProduct удаляет продукт по ID
 func (p *ProductUseCase) DeleteProduct(id uint64) error {
-	return p.productRepo.Delete(id)
+	product, err := p.productRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := p.productRepo.Delete(id); err != nil {
+		return err
+	}
+	p.eventUseCase.Publish(entities.StoreTopic(product.StoreID), enums.ProductDeletedEvent, product)
+	return nil
 }
 
 // GetProductsByStore получает все продукты по ID магазина
 func (p *ProductUseCase) GetProductsByStore(storeID uint64) ([]entities.Product, error) {
 	return p.productRepo.FindAllByStore(storeID)
 }
+
+// publishProductUpdated отправляет в топик магазина событие изменения продукта
+// и отдельное событие для каждого варианта, у которого изменился остаток
+func (p *ProductUseCase) publishProductUpdated(before, after entities.Product) {
+	topic := entities.StoreTopic(after.StoreID)
+	p.eventUseCase.Publish(topic, enums.ProductUpdatedEvent, after)
+
+	for _, variant := range after.Variants {
+		previousStock := 0
+		if old, ok := before.FindVariant(variant.SKU); ok {
+			previousStock = old.Stock
+		}
+		if previousStock == variant.Stock {
+			continue
+		}
+		p.eventUseCase.Publish(topic, enums.StockChangedEvent, entities.StockChange{
+			ProductID:     after.ID,
+			SKU:           variant.SKU,
+			PreviousStock: previousStock,
+			Stock:         variant.Stock,
+		})
+	}
+}
+
+// validateProduct проверяет поля продукта, его опции, варианты и атрибуты категорий
+func (p *ProductUseCase) validateProduct(product entities.Product) error {
+	if err := p.validator.Struct(product); err != nil {
+		return &ValidationError{Err: err}
+	}
+	if err := p.validateVariants(product); err != nil {
+		return &ValidationError{Err: err}
+	}
+	if err := p.validateCategories(product); err != nil {
+		return &ValidationError{Err: err}
+	}
+	return nil
+}
+
+// validateCategories проверяет, что категории существуют и у продукта заданы
+// атрибуты, обязательные для этих категорий и их предков
+func (p *ProductUseCase) validateCategories(product entities.Product) error {
+	for _, categoryID := range product.CategoryIDs {
+		category, err := p.categoryRepo.FindByID(categoryID)
+		if err != nil {
+			return fmt.Errorf("category %d not found", categoryID)
+		}
+
+		// Обходим цепочку предков; visited защищает от циклов в поврежденных данных
+		visited := make(map[uint64]bool)
+		for !visited[category.ID] {
+			visited[category.ID] = true
+			for _, attribute := range category.RequiredAttributes {
+				if strings.TrimSpace(product.Attributes[attribute]) == "" {
+					return fmt.Errorf("attribute %s is required for category %s", attribute, category.Slug)
+				}
+			}
+			if category.ParentID == 0 {
+				break
+			}
+			if category, err = p.categoryRepo.FindByID(category.ParentID); err != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// validateVariants проверяет, что варианты используют только объявленные опции,
+// комбинации опций не повторяются, а артикулы уникальны в маркетплейсе
+func (p *ProductUseCase) validateVariants(product entities.Product) error {
+	allowedValues := make(map[string]map[string]bool, len(product.Options))
+	for _, option := range product.Options {
+		if _, exists := allowedValues[option.Name]; exists {
+			return fmt.Errorf("duplicate option: %s", option.Name)
+		}
+		allowedValues[option.Name] = make(map[string]bool, len(option.Values))
+		for _, value := range option.Values {
+			allowedValues[option.Name][value] = true
+		}
+	}
+
+	skus := make(map[string]bool, len(product.Variants))
+	combinations := make(map[string]string, len(product.Variants))
+	for _, variant := range product.Variants {
+		if skus[variant.SKU] {
+			return fmt.Errorf("duplicate variant sku: %s", variant.SKU)
+		}
+		skus[variant.SKU] = true
+
+		if owner, err := p.productRepo.FindBySKU(variant.SKU); err == nil && owner.ID != product.ID {
+			return fmt.Errorf("sku %s is already used by product %d", variant.SKU, owner.ID)
+		}
+
+		if len(variant.Options) != len(allowedValues) {
+			return fmt.Errorf("variant %s must set every product option", variant.SKU)
+		}
+		for name, value := range variant.Options {
+			values, exists := allowedValues[name]
+			if !exists {
+				return fmt.Errorf("variant %s uses unknown option: %s", variant.SKU, name)
+			}
+			if !values[value] {
+				return fmt.Errorf("variant %s uses unknown value %q for option %s", variant.SKU, value, name)
+			}
+		}
+
+		key := variantCombinationKey(variant)
+		if sku, exists := combinations[key]; exists {
+			return fmt.Errorf("variants %s and %s have the same options", sku, variant.SKU)
+		}
+		combinations[key] = variant.SKU
+	}
+
+	return nil
+}
+
+// variantCombinationKey строит ключ комбинации опций варианта, не зависящий от порядка.
+// Имена и значения экранируются, поэтому разделители внутри них не склеивают разные комбинации.
+func variantCombinationKey(variant entities.ProductVariant) string {
+	pairs := make([]string, 0, len(variant.Options))
+	for name, value := range variant.Options {
+		pairs = append(pairs, strconv.Quote(name)+"="+strconv.Quote(value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
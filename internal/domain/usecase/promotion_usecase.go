@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"strings"
+)
+
+// PromotionUseCase управляет промокодами и автоматическими акциями магазинов
+type PromotionUseCase struct {
+	couponRepo    repository.CouponRepository
+	promotionRepo repository.PromotionRepository
+	storeRepo     repository.StoreRepository
+	categoryRepo  repository.CategoryRepository
+	validator     *validator.Validate
+}
+
+// NewPromotionUseCase создает новый экземпляр PromotionUseCase
+func NewPromotionUseCase(
+	couponRepo repository.CouponRepository,
+	promotionRepo repository.PromotionRepository,
+	storeRepo repository.StoreRepository,
+	categoryRepo repository.CategoryRepository,
+	validate *validator.Validate,
+) *PromotionUseCase {
+	return &PromotionUseCase{
+		couponRepo:    couponRepo,
+		promotionRepo: promotionRepo,
+		storeRepo:     storeRepo,
+		categoryRepo:  categoryRepo,
+		validator:     validate,
+	}
+}
+
+// CreateCoupon создает промокод магазина; доступно только владельцу магазина
+func (u *PromotionUseCase) CreateCoupon(storeID, userID uint64, coupon entities.Coupon) (entities.Coupon, error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return entities.Coupon{}, err
+	}
+
+	coupon.Code = strings.ToUpper(coupon.Code)
+	if err := u.validator.Struct(coupon); err != nil {
+		return entities.Coupon{}, err
+	}
+	if coupon.Type == enums.PercentageDiscount && coupon.Value > 100 {
+		return entities.Coupon{}, errors.New("percentage discount cannot exceed 100")
+	}
+	if !coupon.EndsAt.IsZero() && !coupon.EndsAt.After(coupon.StartsAt) {
+		return entities.Coupon{}, errors.New("coupon must end after it starts")
+	}
+
+	coupon.StoreID = storeID
+	return u.couponRepo.Save(coupon)
+}
+
+// GetStoreCoupons возвращает промокоды магазина его владельцу
+func (u *PromotionUseCase) GetStoreCoupons(storeID, userID uint64) ([]entities.Coupon, error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return nil, err
+	}
+	return u.couponRepo.FindAllByStore(storeID)
+}
+
+// DeleteCoupon удаляет промокод; доступно только владельцу магазина
+func (u *PromotionUseCase) DeleteCoupon(id, userID uint64) error {
+	coupon, err := u.couponRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if _, err = requireStoreOwner(u.storeRepo, coupon.StoreID, userID); err != nil {
+		return err
+	}
+	return u.couponRepo.Delete(id)
+}
+
+// CreatePromotion создает автоматическую акцию магазина; доступно только владельцу магазина
+func (u *PromotionUseCase) CreatePromotion(storeID, userID uint64, promotion entities.Promotion) (entities.Promotion, error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return entities.Promotion{}, err
+	}
+	if err := u.validator.Struct(promotion); err != nil {
+		return entities.Promotion{}, err
+	}
+
+	switch promotion.Type {
+	case enums.BuyXGetYPromotion:
+		if promotion.BuyQuantity < 1 || promotion.FreeQuantity < 1 {
+			return entities.Promotion{}, errors.New("buy_quantity and free_quantity must be positive")
+		}
+	case enums.PercentageOffPromotion:
+		if promotion.Percent <= 0 {
+			return entities.Promotion{}, errors.New("percent must be positive")
+		}
+	}
+	if promotion.CategoryID != 0 {
+		if _, err := u.categoryRepo.FindByID(promotion.CategoryID); err != nil {
+			return entities.Promotion{}, fmt.Errorf("category %d not found", promotion.CategoryID)
+		}
+	}
+	if !promotion.EndsAt.IsZero() && !promotion.EndsAt.After(promotion.StartsAt) {
+		return entities.Promotion{}, errors.New("promotion must end after it starts")
+	}
+
+	promotion.StoreID = storeID
+	return u.promotionRepo.Save(promotion)
+}
+
+// GetStorePromotions возвращает акции магазина
+func (u *PromotionUseCase) GetStorePromotions(storeID uint64) ([]entities.Promotion, error) {
+	if _, err := u.storeRepo.FindByID(storeID); err != nil {
+		return nil, err
+	}
+	return u.promotionRepo.FindAllByStore(storeID)
+}
+
+// DeletePromotion удаляет акцию; доступно только владельцу магазина
+func (u *PromotionUseCase) DeletePromotion(id, userID uint64) error {
+	promotion, err := u.promotionRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if _, err = requireStoreOwner(u.storeRepo, promotion.StoreID, userID); err != nil {
+		return err
+	}
+	return u.promotionRepo.Delete(id)
+}
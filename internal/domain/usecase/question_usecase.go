@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"sort"
+)
+
+// errQuestionNotFound возвращается и для неопубликованных вопросов, чтобы они были неотличимы от несуществующих
+var errQuestionNotFound = errors.New("question not found")
+
+// QuestionUseCase управляет вопросами покупателей о продуктах и ответами продавцов
+type QuestionUseCase struct {
+	questionRepo repository.QuestionRepository
+	productRepo  repository.ProductRepository
+	storeRepo    repository.StoreRepository
+	moderator    repository.ContentModerator
+	validator    *validator.Validate
+}
+
+// NewQuestionUseCase создает новый экземпляр QuestionUseCase
+func NewQuestionUseCase(
+	questionRepo repository.QuestionRepository,
+	productRepo repository.ProductRepository,
+	storeRepo repository.StoreRepository,
+	moderator repository.ContentModerator,
+	validate *validator.Validate,
+) *QuestionUseCase {
+	return &QuestionUseCase{
+		questionRepo: questionRepo,
+		productRepo:  productRepo,
+		storeRepo:    storeRepo,
+		moderator:    moderator,
+		validator:    validate,
+	}
+}
+
+// AskQuestion создает вопрос о продукте; статус публикации определяет модератор
+func (u *QuestionUseCase) AskQuestion(productID, userID uint64, question entities.Question) (entities.Question, error) {
+	if err := u.validator.Struct(question); err != nil {
+		return entities.Question{}, err
+	}
+
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil {
+		return entities.Question{}, err
+	}
+	status, err := u.moderator.Moderate(question.Text)
+	if err != nil {
+		return entities.Question{}, fmt.Errorf("failed to moderate question: %v", err)
+	}
+
+	question.ProductID = product.ID
+	question.StoreID = product.StoreID
+	question.UserID = userID
+	question.Status = status
+	question.AnswerCount = 0
+	return u.questionRepo.SaveQuestion(question)
+}
+
+// AnswerQuestion сохраняет ответ на вопрос; отвечать может только владелец магазина
+func (u *QuestionUseCase) AnswerQuestion(questionID, userID uint64, answer entities.Answer) (entities.Answer, error) {
+	if err := u.validator.Struct(answer); err != nil {
+		return entities.Answer{}, err
+	}
+
+	question, err := u.publishedQuestion(questionID)
+	if err != nil {
+		return entities.Answer{}, err
+	}
+	if _, err := requireStoreOwner(u.storeRepo, question.StoreID, userID); err != nil {
+		return entities.Answer{}, err
+	}
+	status, err := u.moderator.Moderate(answer.Text)
+	if err != nil {
+		return entities.Answer{}, fmt.Errorf("failed to moderate answer: %v", err)
+	}
+
+	answer.QuestionID = question.ID
+	answer.UserID = userID
+	answer.Status = status
+	answer.Upvotes = 0
+	return u.questionRepo.SaveAnswer(answer)
+}
+
+// GetProductQuestions возвращает страницу опубликованных вопросов о продукте, новые первыми,
+// с количеством опубликованных ответов. Свои вопросы пользователь видит и до публикации.
+func (u *QuestionUseCase) GetProductQuestions(productID, userID uint64, page, perPage int) (entities.Page[entities.Question], error) {
+	if _, err := u.productRepo.FindByID(productID); err != nil {
+		return entities.Page[entities.Question]{}, err
+	}
+
+	questions, err := u.questionRepo.FindQuestionsByProduct(productID)
+	if err != nil {
+		return entities.Page[entities.Question]{}, err
+	}
+
+	published := make([]entities.Question, 0, len(questions))
+	for _, question := range questions {
+		if question.Status == enums.PublishedContent || question.UserID == userID {
+			published = append(published, question)
+		}
+	}
+	sort.Slice(published, func(i, j int) bool { return published[i].ID > published[j].ID })
+
+	result := entities.NewPage(published, page, perPage)
+	for i := range result.Items {
+		answers, err := u.publishedAnswers(result.Items[i].ID)
+		if err != nil {
+			return entities.Page[entities.Question]{}, err
+		}
+		result.Items[i].AnswerCount = len(answers)
+	}
+	return result, nil
+}
+
+// GetAnswers возвращает опубликованные ответы на вопрос, самые полезные первыми.
+// Автор видит свой вопрос и свои ответы и до публикации.
+func (u *QuestionUseCase) GetAnswers(questionID, userID uint64) ([]entities.Answer, error) {
+	question, err := u.questionRepo.FindQuestionByID(questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Status != enums.PublishedContent && question.UserID != userID {
+		return nil, errQuestionNotFound
+	}
+
+	all, err := u.questionRepo.FindAnswersByQuestion(questionID)
+	if err != nil {
+		return nil, err
+	}
+	answers := make([]entities.Answer, 0, len(all))
+	for _, answer := range all {
+		if answer.Status != enums.PublishedContent && answer.UserID != userID {
+			continue
+		}
+		if answer, err = u.withUpvotes(answer); err != nil {
+			return nil, err
+		}
+		answers = append(answers, answer)
+	}
+	sort.SliceStable(answers, func(i, j int) bool { return answers[i].Upvotes > answers[j].Upvotes })
+	return answers, nil
+}
+
+// UpvoteAnswer отмечает ответ полезным; каждый пользователь голосует один раз,
+// автор ответа не может голосовать за свой ответ
+func (u *QuestionUseCase) UpvoteAnswer(answerID, userID uint64) (entities.Answer, error) {
+	answer, err := u.publishedAnswer(answerID)
+	if err != nil {
+		return entities.Answer{}, err
+	}
+	if answer.UserID == userID {
+		return entities.Answer{}, fmt.Errorf("%w: cannot upvote own answer", ErrForbidden)
+	}
+
+	if err := u.questionRepo.AddVote(answerID, userID); err != nil {
+		return entities.Answer{}, err
+	}
+	return u.withUpvotes(answer)
+}
+
+// RemoveUpvote отменяет отметку «полезно»
+func (u *QuestionUseCase) RemoveUpvote(answerID, userID uint64) (entities.Answer, error) {
+	answer, err := u.publishedAnswer(answerID)
+	if err != nil {
+		return entities.Answer{}, err
+	}
+
+	if err := u.questionRepo.RemoveVote(answerID, userID); err != nil {
+		return entities.Answer{}, err
+	}
+	return u.withUpvotes(answer)
+}
+
+// GetModerationQuestions возвращает очередь модерации: вопросы со статусом status, старые первыми
+func (u *QuestionUseCase) GetModerationQuestions(status enums.ModerationStatus, page, perPage int) (entities.Page[entities.Question], error) {
+	if err := validateModerationStatus(status); err != nil {
+		return entities.Page[entities.Question]{}, err
+	}
+	questions, err := u.questionRepo.FindQuestionsByStatus(status)
+	if err != nil {
+		return entities.Page[entities.Question]{}, err
+	}
+	return entities.NewPage(questions, page, perPage), nil
+}
+
+// GetModerationAnswers возвращает очередь модерации: ответы со статусом status, старые первыми
+func (u *QuestionUseCase) GetModerationAnswers(status enums.ModerationStatus, page, perPage int) (entities.Page[entities.Answer], error) {
+	if err := validateModerationStatus(status); err != nil {
+		return entities.Page[entities.Answer]{}, err
+	}
+	answers, err := u.questionRepo.FindAnswersByStatus(status)
+	if err != nil {
+		return entities.Page[entities.Answer]{}, err
+	}
+	result := entities.NewPage(answers, page, perPage)
+	for i := range result.Items {
+		if result.Items[i], err = u.withUpvotes(result.Items[i]); err != nil {
+			return entities.Page[entities.Answer]{}, err
+		}
+	}
+	return result, nil
+}
+
+// ModerateQuestion изменяет статус публикации вопроса по решению администратора
+func (u *QuestionUseCase) ModerateQuestion(questionID uint64, decision entities.ModerationDecision) (entities.Question, error) {
+	if err := u.validator.Struct(decision); err != nil {
+		return entities.Question{}, err
+	}
+
+	question, err := u.questionRepo.FindQuestionByID(questionID)
+	if err != nil {
+		return entities.Question{}, err
+	}
+	question.Status = decision.Status
+	if err := u.questionRepo.UpdateQuestion(question); err != nil {
+		return entities.Question{}, err
+	}
+	return question, nil
+}
+
+// ModerateAnswer изменяет статус публикации ответа по решению администратора
+func (u *QuestionUseCase) ModerateAnswer(answerID uint64, decision entities.ModerationDecision) (entities.Answer, error) {
+	if err := u.validator.Struct(decision); err != nil {
+		return entities.Answer{}, err
+	}
+
+	answer, err := u.questionRepo.FindAnswerByID(answerID)
+	if err != nil {
+		return entities.Answer{}, err
+	}
+	answer.Status = decision.Status
+	if err := u.questionRepo.UpdateAnswer(answer); err != nil {
+		return entities.Answer{}, err
+	}
+	return u.withUpvotes(answer)
+}
+
+// validateModerationStatus проверяет, что status — известный статус модерации
+func validateModerationStatus(status enums.ModerationStatus) error {
+	switch status {
+	case enums.PublishedContent, enums.PendingContent, enums.RejectedContent:
+		return nil
+	}
+	return fmt.Errorf("unknown moderation status: %s", status)
+}
+
+// publishedQuestion возвращает вопрос, если он опубликован
+func (u *QuestionUseCase) publishedQuestion(questionID uint64) (entities.Question, error) {
+	question, err := u.questionRepo.FindQuestionByID(questionID)
+	if err != nil {
+		return entities.Question{}, err
+	}
+	if question.Status != enums.PublishedContent {
+		return entities.Question{}, errQuestionNotFound
+	}
+	return question, nil
+}
+
+// publishedAnswer возвращает ответ, если опубликованы и он, и вопрос
+func (u *QuestionUseCase) publishedAnswer(answerID uint64) (entities.Answer, error) {
+	answer, err := u.questionRepo.FindAnswerByID(answerID)
+	if err != nil {
+		return entities.Answer{}, err
+	}
+	if answer.Status != enums.PublishedContent {
+		return entities.Answer{}, errors.New("answer not found")
+	}
+	if _, err := u.publishedQuestion(answer.QuestionID); err != nil {
+		return entities.Answer{}, err
+	}
+	return answer, nil
+}
+
+// publishedAnswers возвращает опубликованные ответы на вопрос с количеством голосов
+func (u *QuestionUseCase) publishedAnswers(questionID uint64) ([]entities.Answer, error) {
+	answers, err := u.questionRepo.FindAnswersByQuestion(questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	published := make([]entities.Answer, 0, len(answers))
+	for _, answer := range answers {
+		if answer.Status != enums.PublishedContent {
+			continue
+		}
+		if answer, err = u.withUpvotes(answer); err != nil {
+			return nil, err
+		}
+		published = append(published, answer)
+	}
+	return published, nil
+}
+
+// withUpvotes заполняет количество голосов за ответ
+func (u *QuestionUseCase) withUpvotes(answer entities.Answer) (entities.Answer, error) {
+	upvotes, err := u.questionRepo.CountVotes(answer.ID)
+	if err != nil {
+		return entities.Answer{}, err
+	}
+	answer.Upvotes = upvotes
+	return answer, nil
+}
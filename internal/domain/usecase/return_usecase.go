@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"sort"
+	"sync"
+)
+
+// errReturnNotFound возвращается и для чужих заявок, чтобы они были неотличимы от несуществующих
+var errReturnNotFound = errors.New("return request not found")
+
+// ReturnUseCase ведет заявки на возврат товаров. Заказов пока нет, поэтому возврат
+// оформляется по позиции оплаченного счета, а деньги возвращаются через оплату счета.
+type ReturnUseCase struct {
+	returnRepo     repository.ReturnRepository
+	invoiceRepo    repository.InvoiceRepository
+	storeRepo      repository.StoreRepository
+	productUseCase *ProductUseCase
+	paymentUseCase *PaymentUseCase
+	storage        repository.PrivateBlobStorage
+	validator      *validator.Validate
+	// refundMu не дает вернуть деньги по одной заявке дважды
+	refundMu sync.Mutex
+}
+
+// NewReturnUseCase создает новый экземпляр ReturnUseCase
+func NewReturnUseCase(
+	returnRepo repository.ReturnRepository,
+	invoiceRepo repository.InvoiceRepository,
+	storeRepo repository.StoreRepository,
+	productUseCase *ProductUseCase,
+	paymentUseCase *PaymentUseCase,
+	storage repository.PrivateBlobStorage,
+	validate *validator.Validate,
+) *ReturnUseCase {
+	return &ReturnUseCase{
+		returnRepo:     returnRepo,
+		invoiceRepo:    invoiceRepo,
+		storeRepo:      storeRepo,
+		productUseCase: productUseCase,
+		paymentUseCase: paymentUseCase,
+		storage:        storage,
+		validator:      validate,
+	}
+}
+
+// RequestReturn оформляет заявку покупателя на возврат позиции оплаченного счета
+func (u *ReturnUseCase) RequestReturn(invoiceID, userID uint64, input entities.ReturnRequestInput) (entities.ReturnRequest, error) {
+	if err := u.validator.Struct(input); err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	invoice, err := u.invoiceRepo.FindByID(invoiceID)
+	if err != nil || invoice.BuyerID != userID {
+		return entities.ReturnRequest{}, errInvoiceNotFound
+	}
+	if _, err = u.paymentUseCase.GetInvoicePayment(invoice.ID, userID); err != nil {
+		return entities.ReturnRequest{}, errors.New("invoice is not paid")
+	}
+	if input.LineIndex >= len(invoice.Lines) {
+		return entities.ReturnRequest{}, fmt.Errorf("invoice has no line %d", input.LineIndex)
+	}
+	line := invoice.Lines[input.LineIndex]
+
+	// Репозиторий учитывает единицы позиции, уже заявленные к возврату
+	return u.returnRepo.Save(entities.ReturnRequest{
+		InvoiceID: invoice.ID,
+		StoreID:   invoice.StoreID,
+		BuyerID:   userID,
+		LineIndex: input.LineIndex,
+		ProductID: line.ProductID,
+		SKU:       line.SKU,
+		Quantity:  input.Quantity,
+		Reason:    input.Reason,
+		Status:    enums.ReturnRequested,
+	}, line.Quantity)
+}
+
+// AddPhoto добавляет фотографию к заявке до ее рассмотрения; доступно только покупателю
+func (u *ReturnUseCase) AddPhoto(returnID, userID uint64, data []byte) (entities.ReturnRequest, error) {
+	if len(data) > constants.MaxProductImageSize {
+		return entities.ReturnRequest{}, fmt.Errorf("image exceeds %d bytes", constants.MaxProductImageSize)
+	}
+	contentType := mimetype.Detect(data).String()
+	extension, ok := allowedImageTypes[contentType]
+	if !ok {
+		return entities.ReturnRequest{}, fmt.Errorf("unsupported image type: %s", contentType)
+	}
+
+	request, err := u.returnRepo.FindByID(returnID)
+	if err != nil || request.BuyerID != userID {
+		return entities.ReturnRequest{}, errReturnNotFound
+	}
+	if request.Status != enums.ReturnRequested {
+		return entities.ReturnRequest{}, fmt.Errorf("return request is %s", request.Status)
+	}
+	if len(request.Photos) >= constants.MaxReturnPhotosCount {
+		return entities.ReturnRequest{}, fmt.Errorf("return request already has %d photos", constants.MaxReturnPhotosCount)
+	}
+
+	name := uuid.New().String() + extension
+	key := returnPhotoKey(request.ID, name)
+	if err = u.storage.Put(key, data, contentType); err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	photo := entities.ReturnPhoto{
+		Name:        name,
+		URL:         fmt.Sprintf("/returns/%d/photos/%s", request.ID, name),
+		ContentType: contentType,
+	}
+	// Лимит и состояние проверяются повторно в репозитории
+	request, err = u.returnRepo.AppendPhoto(request.ID, photo, constants.MaxReturnPhotosCount)
+	if err != nil {
+		_ = u.storage.Delete(key)
+		return entities.ReturnRequest{}, err
+	}
+	return request, nil
+}
+
+// GetPhoto возвращает фотографию заявки и ее тип покупателю или продавцу
+func (u *ReturnUseCase) GetPhoto(returnID, userID uint64, name string) ([]byte, string, error) {
+	request, err := u.GetReturn(returnID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, photo := range request.Photos {
+		if photo.Name != name {
+			continue
+		}
+		data, err := u.storage.Get(returnPhotoKey(request.ID, name))
+		if err != nil {
+			return nil, "", errors.New("photo not found")
+		}
+		return data, photo.ContentType, nil
+	}
+	return nil, "", errors.New("photo not found")
+}
+
+// GetReturn возвращает заявку покупателю или владельцу магазина
+func (u *ReturnUseCase) GetReturn(returnID, userID uint64) (entities.ReturnRequest, error) {
+	request, err := u.returnRepo.FindByID(returnID)
+	if err != nil {
+		return entities.ReturnRequest{}, errReturnNotFound
+	}
+	if request.BuyerID == userID {
+		return request, nil
+	}
+	if store, err := u.storeRepo.FindByID(request.StoreID); err == nil && store.OwnerID == userID {
+		return request, nil
+	}
+	return entities.ReturnRequest{}, errReturnNotFound
+}
+
+// GetUserReturns возвращает заявки покупателя, новые первыми
+func (u *ReturnUseCase) GetUserReturns(userID uint64, page, perPage int) (entities.Page[entities.ReturnRequest], error) {
+	requests, err := u.returnRepo.FindAllByBuyer(userID)
+	if err != nil {
+		return entities.Page[entities.ReturnRequest]{}, err
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].ID > requests[j].ID })
+	return entities.NewPage(requests, page, perPage), nil
+}
+
+// GetStoreReturns возвращает заявки на возврат в магазин его владельцу, новые первыми
+func (u *ReturnUseCase) GetStoreReturns(storeID, userID uint64, page, perPage int) (entities.Page[entities.ReturnRequest], error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return entities.Page[entities.ReturnRequest]{}, err
+	}
+
+	requests, err := u.returnRepo.FindAllByStore(storeID)
+	if err != nil {
+		return entities.Page[entities.ReturnRequest]{}, err
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].ID > requests[j].ID })
+	return entities.NewPage(requests, page, perPage), nil
+}
+
+// ApproveReturn принимает заявку: покупатель может отправлять товар
+func (u *ReturnUseCase) ApproveReturn(returnID, userID uint64, decision entities.ReturnDecision) (entities.ReturnRequest, error) {
+	return u.decide(returnID, userID, decision, enums.ReturnApproved)
+}
+
+// RejectReturn отклоняет заявку; отклоненные единицы можно заявить к возврату снова
+func (u *ReturnUseCase) RejectReturn(returnID, userID uint64, decision entities.ReturnDecision) (entities.ReturnRequest, error) {
+	return u.decide(returnID, userID, decision, enums.ReturnRejected)
+}
+
+// ShipReturn сохраняет трек-номер отправки одобренного возврата; доступно только покупателю
+func (u *ReturnUseCase) ShipReturn(returnID, userID uint64, shipment entities.ReturnShipment) (entities.ReturnRequest, error) {
+	if err := u.validator.Struct(shipment); err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	request, err := u.returnRepo.FindByID(returnID)
+	if err != nil || request.BuyerID != userID {
+		return entities.ReturnRequest{}, errReturnNotFound
+	}
+
+	request.Shipment = &shipment
+	request.Status = enums.ReturnShipped
+	return u.returnRepo.Transition(request, enums.ReturnApproved)
+}
+
+// ReceiveReturn отмечает получение товара продавцом и возвращает его на остаток
+func (u *ReturnUseCase) ReceiveReturn(returnID, userID uint64) (entities.ReturnRequest, error) {
+	request, err := u.storeReturn(returnID, userID)
+	if err != nil {
+		return entities.ReturnRequest{}, err
+	}
+
+	request.Status = enums.ReturnReceived
+	request, err = u.returnRepo.Transition(request, enums.ReturnShipped)
+	if err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	// Остаток ведется только у вариантов; продукт мог быть удален после продажи
+	if request.SKU != "" {
+		if err = u.productUseCase.Restock(request.ProductID, request.StoreID, request.SKU, request.Quantity); err != nil {
+			logrus.Errorf("Failed to restock %s after return %d: %v", request.SKU, request.ID, err)
+		}
+	}
+	return request, nil
+}
+
+// RefundReturn возвращает покупателю деньги за полученный товар. Сумма по умолчанию —
+// стоимость возвращаемых единиц после скидок вместе с налогом, начисленным сверх цены;
+// меньшая сумма означает частичный возврат.
+func (u *ReturnUseCase) RefundReturn(returnID, userID uint64, input entities.ReturnRefundInput) (entities.ReturnRequest, error) {
+	if err := u.validator.Struct(input); err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	request, err := u.storeReturn(returnID, userID)
+	if err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	invoice, err := u.invoiceRepo.FindByID(request.InvoiceID)
+	if err != nil {
+		return entities.ReturnRequest{}, err
+	}
+
+	refundable := returnValue(invoice, request)
+	amount := utils.RoundPrice(input.Amount)
+	if amount == 0 {
+		amount = refundable
+	}
+	if amount > refundable {
+		return entities.ReturnRequest{}, fmt.Errorf("refund exceeds the value of returned items %.2f", refundable)
+	}
+
+	u.refundMu.Lock()
+	defer u.refundMu.Unlock()
+
+	// Состояние проверяется повторно под блокировкой до движения денег
+	current, err := u.returnRepo.FindByID(request.ID)
+	if err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	if current.Status != enums.ReturnReceived {
+		return entities.ReturnRequest{}, fmt.Errorf("return request is %s", current.Status)
+	}
+	if amount > 0 {
+		if _, err = u.paymentUseCase.RefundInvoice(invoice.ID, amount); err != nil {
+			return entities.ReturnRequest{}, err
+		}
+	}
+
+	request.RefundAmount = amount
+	request.Status = enums.ReturnRefunded
+	return u.returnRepo.Transition(request, enums.ReturnReceived)
+}
+
+// decide сохраняет решение продавца по заявке, ожидающей рассмотрения
+func (u *ReturnUseCase) decide(returnID, userID uint64, decision entities.ReturnDecision, status enums.ReturnStatus) (entities.ReturnRequest, error) {
+	if err := u.validator.Struct(decision); err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	request, err := u.storeReturn(returnID, userID)
+	if err != nil {
+		return entities.ReturnRequest{}, err
+	}
+
+	request.SellerComment = decision.Comment
+	request.Status = status
+	return u.returnRepo.Transition(request, enums.ReturnRequested)
+}
+
+// storeReturn возвращает заявку, если пользователь владеет магазином, в который она подана
+func (u *ReturnUseCase) storeReturn(returnID, userID uint64) (entities.ReturnRequest, error) {
+	request, err := u.returnRepo.FindByID(returnID)
+	if err != nil {
+		return entities.ReturnRequest{}, errReturnNotFound
+	}
+	if _, err = requireStoreOwner(u.storeRepo, request.StoreID, userID); err != nil {
+		return entities.ReturnRequest{}, err
+	}
+	return request, nil
+}
+
+// returnValue возвращает долю оплаты позиции счета, приходящуюся на возвращаемые единицы
+func returnValue(invoice entities.Invoice, request entities.ReturnRequest) float64 {
+	line := invoice.Lines[request.LineIndex]
+	paid := line.Total
+	for _, taxLine := range invoice.TaxLines {
+		if taxLine.LineIndex == request.LineIndex && !taxLine.Included {
+			paid += taxLine.Amount
+		}
+	}
+	return utils.RoundPrice(paid * float64(request.Quantity) / float64(line.Quantity))
+}
+
+// returnPhotoKey путь фотографии заявки в закрытом хранилище
+func returnPhotoKey(returnID uint64, name string) string {
+	return fmt.Sprintf("returns/%d/%s", returnID, name)
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Варианты сортировки отзывов
+const (
+	ReviewSortNewest     = "newest"
+	ReviewSortOldest     = "oldest"
+	ReviewSortRatingDesc = "rating_desc"
+	ReviewSortRatingAsc  = "rating_asc"
+)
+
+// ReviewUseCase управляет отзывами и поддерживает агрегированные рейтинги продуктов и магазинов
+type ReviewUseCase struct {
+	reviewRepo  repository.ReviewRepository
+	productRepo repository.ProductRepository
+	storeRepo   repository.StoreRepository
+	invoiceRepo repository.InvoiceRepository
+	paymentRepo repository.PaymentRepository
+	storage     repository.BlobStorage
+	validator   *validator.Validate
+	mu          sync.Mutex // Сериализует пересчет рейтингов
+}
+
+// NewReviewUseCase создает новый экземпляр ReviewUseCase
+func NewReviewUseCase(
+	reviewRepo repository.ReviewRepository,
+	productRepo repository.ProductRepository,
+	storeRepo repository.StoreRepository,
+	invoiceRepo repository.InvoiceRepository,
+	paymentRepo repository.PaymentRepository,
+	storage repository.BlobStorage,
+	validate *validator.Validate,
+) *ReviewUseCase {
+	return &ReviewUseCase{
+		reviewRepo:  reviewRepo,
+		productRepo: productRepo,
+		storeRepo:   storeRepo,
+		invoiceRepo: invoiceRepo,
+		paymentRepo: paymentRepo,
+		storage:     storage,
+		validator:   validate,
+	}
+}
+
+// CreateReview создает отзыв покупателя о продукте и пересчитывает рейтинги.
+// Отзыв может оставить только пользователь, оплативший счет с этим продуктом.
+func (u *ReviewUseCase) CreateReview(productID, userID uint64, review entities.Review) (entities.Review, error) {
+	if err := u.validator.Struct(review); err != nil {
+		return entities.Review{}, err
+	}
+
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil {
+		return entities.Review{}, err
+	}
+	if product.OwnerID == userID {
+		return entities.Review{}, fmt.Errorf("%w: sellers cannot review their own products", ErrForbidden)
+	}
+	purchased, err := u.hasPurchased(product.ID, userID)
+	if err != nil {
+		return entities.Review{}, err
+	}
+	if !purchased {
+		return entities.Review{}, fmt.Errorf("%w: only buyers of the product can review it", ErrForbidden)
+	}
+
+	review.ProductID = product.ID
+	review.StoreID = product.StoreID
+	review.UserID = userID
+	review.Photos = nil
+	review.Reply = nil
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	review, err = u.reviewRepo.Save(review)
+	if err != nil {
+		return entities.Review{}, err
+	}
+	if err = u.recalculateRatings(product.ID, product.StoreID); err != nil {
+		return entities.Review{}, err
+	}
+	return review, nil
+}
+
+// ReplyToReview сохраняет ответ владельца магазина на отзыв
+func (u *ReviewUseCase) ReplyToReview(reviewID, userID uint64, reply entities.ReviewReply) (entities.Review, error) {
+	if err := u.validator.Struct(reply); err != nil {
+		return entities.Review{}, err
+	}
+
+	review, err := u.reviewRepo.FindByID(reviewID)
+	if err != nil {
+		return entities.Review{}, err
+	}
+	store, err := u.storeRepo.FindByID(review.StoreID)
+	if err != nil {
+		return entities.Review{}, err
+	}
+	if store.OwnerID != userID {
+		return entities.Review{}, ErrForbidden
+	}
+
+	reply.UserID = userID
+	reply.CreatedAt = time.Now()
+	return u.reviewRepo.SetReply(review.ID, reply)
+}
+
+// AddPhoto добавляет фотографию к отзыву; доступно только автору отзыва
+func (u *ReviewUseCase) AddPhoto(reviewID, userID uint64, data []byte) (entities.Review, error) {
+	if len(data) > constants.MaxProductImageSize {
+		return entities.Review{}, fmt.Errorf("image exceeds %d bytes", constants.MaxProductImageSize)
+	}
+	contentType := mimetype.Detect(data).String()
+	extension, ok := allowedImageTypes[contentType]
+	if !ok {
+		return entities.Review{}, fmt.Errorf("unsupported image type: %s", contentType)
+	}
+
+	review, err := u.reviewRepo.FindByID(reviewID)
+	if err != nil {
+		return entities.Review{}, err
+	}
+	if review.UserID != userID {
+		return entities.Review{}, ErrForbidden
+	}
+	if len(review.Photos) >= constants.MaxReviewPhotosCount {
+		return entities.Review{}, fmt.Errorf("review already has %d photos", constants.MaxReviewPhotosCount)
+	}
+
+	key := fmt.Sprintf("reviews/%d/%s%s", review.ID, uuid.New().String(), extension)
+	if err = u.storage.Put(key, data, contentType); err != nil {
+		return entities.Review{}, err
+	}
+	// Лимит проверяется повторно в репозитории: параллельные загрузки не должны его превысить
+	review, err = u.reviewRepo.AppendPhoto(review.ID, u.storage.URL(key), constants.MaxReviewPhotosCount)
+	if err != nil {
+		_ = u.storage.Delete(key)
+		return entities.Review{}, err
+	}
+	return review, nil
+}
+
+// GetProductReviews возвращает отсортированную страницу отзывов о продукте
+func (u *ReviewUseCase) GetProductReviews(productID uint64, sortBy string, page, perPage int) (entities.Page[entities.Review], error) {
+	if _, err := u.productRepo.FindByID(productID); err != nil {
+		return entities.Page[entities.Review]{}, err
+	}
+
+	reviews, err := u.reviewRepo.FindAllByProduct(productID)
+	if err != nil {
+		return entities.Page[entities.Review]{}, err
+	}
+
+	var less func(a, b entities.Review) bool
+	switch sortBy {
+	case "", ReviewSortNewest:
+		less = func(a, b entities.Review) bool { return a.CreatedAt.After(b.CreatedAt) }
+	case ReviewSortOldest:
+		less = func(a, b entities.Review) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case ReviewSortRatingDesc:
+		less = func(a, b entities.Review) bool { return a.Rating > b.Rating }
+	case ReviewSortRatingAsc:
+		less = func(a, b entities.Review) bool { return a.Rating < b.Rating }
+	default:
+		return entities.Page[entities.Review]{}, errors.New("unknown sort: " + sortBy)
+	}
+	// ID добавляет детерминированный порядок при равных значениях
+	sort.Slice(reviews, func(i, j int) bool {
+		if less(reviews[i], reviews[j]) != less(reviews[j], reviews[i]) {
+			return less(reviews[i], reviews[j])
+		}
+		return reviews[i].ID > reviews[j].ID
+	})
+
+	return entities.NewPage(reviews, page, perPage), nil
+}
+
+// recalculateRatings пересчитывает среднюю оценку и количество отзывов продукта и магазина
+func (u *ReviewUseCase) recalculateRatings(productID, storeID uint64) error {
+	productReviews, err := u.reviewRepo.FindAllByProduct(productID)
+	if err != nil {
+		return err
+	}
+	rating, count := averageRating(productReviews)
+	if err = u.productRepo.UpdateRating(productID, rating, count); err != nil {
+		return err
+	}
+
+	if _, err = u.storeRepo.FindByID(storeID); err != nil {
+		// Продукт может ссылаться на удаленный магазин
+		return nil
+	}
+	storeReviews, err := u.reviewRepo.FindAllByStore(storeID)
+	if err != nil {
+		return err
+	}
+	rating, count = averageRating(storeReviews)
+	return u.storeRepo.UpdateRating(storeID, rating, count)
+}
+
+// hasPurchased проверяет, что пользователь оплатил счет, в котором есть продукт
+func (u *ReviewUseCase) hasPurchased(productID, userID uint64) (bool, error) {
+	payments, err := u.paymentRepo.FindAllByBuyer(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, payment := range payments {
+		invoice, err := u.invoiceRepo.FindByID(payment.InvoiceID)
+		if err != nil {
+			continue
+		}
+		for _, line := range invoice.Lines {
+			if line.ProductID == productID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// averageRating возвращает среднюю оценку, округленную до сотых, и количество отзывов
+func averageRating(reviews []entities.Review) (float64, int) {
+	if len(reviews) == 0 {
+		return 0, 0
+	}
+	total := 0
+	for _, review := range reviews {
+		total += review.Rating
+	}
+	return math.Round(float64(total)/float64(len(reviews))*100) / 100, len(reviews)
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/utils"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ShippingUseCase управляет профилями доставки магазинов и расчетом стоимости доставки
+type ShippingUseCase struct {
+	profileRepo    repository.ShippingProfileRepository
+	storeRepo      repository.StoreRepository
+	productRepo    repository.ProductRepository
+	pricingUseCase *PricingUseCase
+	validator      *validator.Validate
+}
+
+// NewShippingUseCase создает новый экземпляр ShippingUseCase
+func NewShippingUseCase(
+	profileRepo repository.ShippingProfileRepository,
+	storeRepo repository.StoreRepository,
+	productRepo repository.ProductRepository,
+	pricingUseCase *PricingUseCase,
+	validate *validator.Validate,
+) *ShippingUseCase {
+	return &ShippingUseCase{
+		profileRepo:    profileRepo,
+		storeRepo:      storeRepo,
+		productRepo:    productRepo,
+		pricingUseCase: pricingUseCase,
+		validator:      validate,
+	}
+}
+
+// SaveProfile сохраняет профиль доставки магазина; доступно только владельцу магазина
+func (u *ShippingUseCase) SaveProfile(storeID, userID uint64, profile entities.ShippingProfile) (entities.ShippingProfile, error) {
+	if _, err := requireStoreOwner(u.storeRepo, storeID, userID); err != nil {
+		return entities.ShippingProfile{}, err
+	}
+	if err := u.validator.Struct(profile); err != nil {
+		return entities.ShippingProfile{}, err
+	}
+
+	codes := make(map[string]bool, len(profile.Methods))
+	for _, method := range profile.Methods {
+		if codes[method.Code] {
+			return entities.ShippingProfile{}, fmt.Errorf("duplicate shipping method: %s", method.Code)
+		}
+		codes[method.Code] = true
+	}
+
+	profile.StoreID = storeID
+	if err := u.profileRepo.Save(profile); err != nil {
+		return entities.ShippingProfile{}, err
+	}
+	return u.profileRepo.FindByStore(storeID)
+}
+
+// GetProfile возвращает профиль доставки магазина
+func (u *ShippingUseCase) GetProfile(storeID uint64) (entities.ShippingProfile, error) {
+	return u.profileRepo.FindByStore(storeID)
+}
+
+// Quote возвращает доступные способы доставки для позиций каждого магазина корзины
+func (u *ShippingUseCase) Quote(userID uint64, request entities.ShippingQuoteRequest) ([]entities.StoreShippingQuote, error) {
+	// Порог бесплатной доставки сравнивается с суммой после скидок
+	cart, err := u.pricingUseCase.Quote(userID, request.PricingRequest)
+	if err != nil {
+		return nil, err
+	}
+	if cart.Destination == nil {
+		return nil, errors.New("address_id or destination is required")
+	}
+
+	quotes := make(map[uint64]*entities.StoreShippingQuote)
+	for _, line := range cart.Lines {
+		product, err := u.productRepo.FindByID(line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		quote, exists := quotes[line.StoreID]
+		if !exists {
+			quote = &entities.StoreShippingQuote{StoreID: line.StoreID, Options: []entities.ShippingOption{}}
+			quotes[line.StoreID] = quote
+		}
+		quote.Subtotal = utils.RoundPrice(quote.Subtotal + line.Total)
+		quote.WeightGrams += product.WeightGrams * line.Quantity
+	}
+
+	result := make([]entities.StoreShippingQuote, 0, len(quotes))
+	for _, quote := range quotes {
+		// Магазин без профиля доставки возвращается без доступных способов
+		if profile, err := u.profileRepo.FindByStore(quote.StoreID); err == nil {
+			quote.Options = shippingOptions(profile, *cart.Destination, quote.Subtotal, quote.WeightGrams)
+		}
+		result = append(result, *quote)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StoreID < result[j].StoreID })
+	return result, nil
+}
+
+// shippingOptions рассчитывает стоимость каждого способа доставки, у которого есть тариф для адреса
+func shippingOptions(
+	profile entities.ShippingProfile,
+	destination entities.ShippingDestination,
+	subtotal float64,
+	weightGrams int,
+) []entities.ShippingOption {
+	options := []entities.ShippingOption{}
+	for _, method := range profile.Methods {
+		rate, ok := matchShippingRate(method.Rates, destination)
+		if !ok {
+			continue
+		}
+
+		option := entities.ShippingOption{
+			MethodCode: method.Code,
+			Name:       method.Name,
+			MinDays:    method.MinDays,
+			MaxDays:    method.MaxDays,
+		}
+		if rate.FreeShippingThreshold > 0 && subtotal >= rate.FreeShippingThreshold {
+			option.FreeShipping = true
+		} else {
+			option.Cost = shippingCost(rate, weightGrams)
+		}
+		options = append(options, option)
+	}
+	sort.SliceStable(options, func(i, j int) bool { return options[i].Cost < options[j].Cost })
+	return options
+}
+
+// matchShippingRate выбирает тариф самой узкой зоны, подходящей под адрес:
+// совпадение по префиксу индекса важнее совпадения по региону, а оно — по стране
+func matchShippingRate(rates []entities.ShippingRate, destination entities.ShippingDestination) (entities.ShippingRate, bool) {
+	var best entities.ShippingRate
+	bestScore := -1
+	for _, rate := range rates {
+		score := shippingZoneScore(rate.Zone, destination)
+		if score > bestScore {
+			best, bestScore = rate, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// shippingZoneScore возвращает специфичность совпадения зоны с адресом или -1, если зона не подходит
+func shippingZoneScore(zone entities.ShippingZone, destination entities.ShippingDestination) int {
+	if !strings.EqualFold(zone.Country, destination.Country) {
+		return -1
+	}
+	score := 0
+
+	if len(zone.Regions) > 0 {
+		matched := false
+		for _, region := range zone.Regions {
+			if strings.EqualFold(strings.TrimSpace(region), strings.TrimSpace(destination.Region)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return -1
+		}
+		score = 1
+	}
+
+	if len(zone.PostcodePrefixes) > 0 {
+		postcode := strings.ReplaceAll(strings.ToUpper(destination.Postcode), " ", "")
+		longest := -1
+		for _, prefix := range zone.PostcodePrefixes {
+			prefix = strings.ReplaceAll(strings.ToUpper(prefix), " ", "")
+			if strings.HasPrefix(postcode, prefix) && len(prefix) > longest {
+				longest = len(prefix)
+			}
+		}
+		if longest < 0 {
+			return -1
+		}
+		score = 2 + longest
+	}
+	return score
+}
+
+// shippingCost рассчитывает стоимость доставки по тарифу
+func shippingCost(rate entities.ShippingRate, weightGrams int) float64 {
+	switch rate.Type {
+	case enums.WeightShippingRate:
+		kilograms := math.Ceil(float64(weightGrams) / 1000)
+		return utils.RoundPrice(rate.Price + rate.PricePerKg*kilograms)
+	default:
+		return utils.RoundPrice(rate.Price)
+	}
+}
@@ -17,6 +17,8 @@ func NewStoreUseCase(storeRepo repository.StoreRepository) *StoreUseCase {
 
 // CreateStore создает новый магазин
 func (s *StoreUseCase) CreateStore(store entities.Store) error {
+	store.Rating = 0
+	store.ReviewCount = 0
 	return s.storeRepo.Save(store)
 }
 
@@ -27,6 +29,7 @@ func (s *StoreUseCase) GetStoreByID(id uint64) (entities.Store, error) {
 
 // UpdateStore обновляет существующий магазин
 func (s *StoreUseCase) UpdateStore(store entities.Store) error {
+	// Рейтинг магазина изменяется только через ReviewUseCase, репозиторий сохраняет текущий
 	return s.storeRepo.Update(store)
 }
 
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"github.com/go-playground/validator/v10"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"strings"
+)
+
+// TaxUseCase управляет таблицей налоговых ставок
+type TaxUseCase struct {
+	rateRepo  repository.TaxRateRepository
+	validator *validator.Validate
+}
+
+// NewTaxUseCase создает новый экземпляр TaxUseCase
+func NewTaxUseCase(rateRepo repository.TaxRateRepository, validate *validator.Validate) *TaxUseCase {
+	return &TaxUseCase{rateRepo: rateRepo, validator: validate}
+}
+
+// CreateRate добавляет налоговую ставку для страны или региона
+func (u *TaxUseCase) CreateRate(rate entities.TaxRate) (entities.TaxRate, error) {
+	// Страна и регион хранятся в верхнем регистре, чтобы ставки региона не дублировались
+	rate.Country = strings.ToUpper(strings.TrimSpace(rate.Country))
+	rate.Region = strings.ToUpper(strings.TrimSpace(rate.Region))
+	if err := u.validator.Struct(rate); err != nil {
+		return entities.TaxRate{}, err
+	}
+	return u.rateRepo.Save(rate)
+}
+
+// DeleteRate удаляет налоговую ставку
+func (u *TaxUseCase) DeleteRate(id uint64) error {
+	return u.rateRepo.Delete(id)
+}
+
+// GetRates возвращает все налоговые ставки
+func (u *TaxUseCase) GetRates() ([]entities.TaxRate, error) {
+	return u.rateRepo.FindAll()
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errInvalidTwoFactorCode = errors.New("invalid two-factor code")
+
+// recoveryCodeLength длина резервного кода без дефиса
+const recoveryCodeLength = 10
+
+// TwoFactorUseCase управляет двухфакторной аутентификацией по TOTP (RFC 6238)
+type TwoFactorUseCase struct {
+	userRepo repository.UserRepository
+	mu       sync.Mutex // Сериализует изменения TwoFactorState; остальные поля пользователя меняются отдельно
+}
+
+// NewTwoFactorUseCase создает новый экземпляр TwoFactorUseCase
+func NewTwoFactorUseCase(userRepo repository.UserRepository) *TwoFactorUseCase {
+	return &TwoFactorUseCase{userRepo: userRepo}
+}
+
+// Setup создает новый секрет TOTP. Двухфакторная аутентификация включится,
+// когда пользователь подтвердит секрет первым кодом.
+func (u *TwoFactorUseCase) Setup(userID uint64) (entities.TwoFactorSetup, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return entities.TwoFactorSetup{}, err
+	}
+	if user.TwoFactorEnabled {
+		return entities.TwoFactorSetup{}, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return entities.TwoFactorSetup{}, err
+	}
+	user.TOTPSecret = secret
+	if err = u.userRepo.SetTwoFactor(user.ID, user.TwoFactorState); err != nil {
+		return entities.TwoFactorSetup{}, err
+	}
+	return entities.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(user.Email, secret),
+	}, nil
+}
+
+// Enable включает двухфакторную аутентификацию после проверки кода и выдает резервные коды
+func (u *TwoFactorUseCase) Enable(userID uint64, code string) (entities.RecoveryCodes, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return entities.RecoveryCodes{}, err
+	}
+	if user.TwoFactorEnabled {
+		return entities.RecoveryCodes{}, errors.New("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return entities.RecoveryCodes{}, errors.New("two-factor setup has not been started")
+	}
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok || step <= user.TOTPLastStep {
+		return entities.RecoveryCodes{}, errInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return entities.RecoveryCodes{}, err
+	}
+	user.TwoFactorEnabled = true
+	user.RecoveryCodes = hashes
+	user.TOTPLastStep = step
+	if err = u.userRepo.SetTwoFactor(user.ID, user.TwoFactorState); err != nil {
+		return entities.RecoveryCodes{}, err
+	}
+	return entities.RecoveryCodes{Codes: codes}, nil
+}
+
+// Disable отключает двухфакторную аутентификацию по коду или резервному коду
+func (u *TwoFactorUseCase) Disable(userID uint64, code string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, err := u.verify(userID, code)
+	if err != nil {
+		return err
+	}
+	// Шаг последнего кода сохраняется, чтобы его нельзя было применить после повторного включения
+	return u.userRepo.SetTwoFactor(user.ID, entities.TwoFactorState{TOTPLastStep: user.TOTPLastStep})
+}
+
+// RegenerateRecoveryCodes заменяет резервные коды новыми
+func (u *TwoFactorUseCase) RegenerateRecoveryCodes(userID uint64, code string) (entities.RecoveryCodes, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, err := u.verify(userID, code)
+	if err != nil {
+		return entities.RecoveryCodes{}, err
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return entities.RecoveryCodes{}, err
+	}
+	user.RecoveryCodes = hashes
+	if err = u.userRepo.SetTwoFactor(user.ID, user.TwoFactorState); err != nil {
+		return entities.RecoveryCodes{}, err
+	}
+	return entities.RecoveryCodes{Codes: codes}, nil
+}
+
+// Verify проверяет код второго фактора при входе
+func (u *TwoFactorUseCase) Verify(userID uint64, code string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	_, err := u.verify(userID, code)
+	return err
+}
+
+// verify проверяет код TOTP или резервный код. Использованный резервный код удаляется,
+// а код TOTP нельзя применить повторно, пока он остается в окне допустимого расхождения.
+func (u *TwoFactorUseCase) verify(userID uint64, code string) (entities.User, error) {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return entities.User{}, err
+	}
+	if !user.TwoFactorEnabled {
+		return entities.User{}, errors.New("two-factor authentication is not enabled")
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		if step <= user.TOTPLastStep {
+			return entities.User{}, errInvalidTwoFactorCode
+		}
+		user.TOTPLastStep = step
+		if err = u.userRepo.SetTwoFactor(user.ID, user.TwoFactorState); err != nil {
+			return entities.User{}, err
+		}
+		return user, nil
+	}
+
+	// Коды TOTP состоят из цифр, поэтому дорогие сравнения bcrypt выполняются
+	// только для строк в формате резервного кода
+	normalized := strings.ToLower(strings.ReplaceAll(code, "-", ""))
+	if len(normalized) != recoveryCodeLength {
+		return entities.User{}, errInvalidTwoFactorCode
+	}
+	for i, hash := range user.RecoveryCodes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(normalized)) == nil {
+			user.RecoveryCodes = append(user.RecoveryCodes[:i:i], user.RecoveryCodes[i+1:]...)
+			if err = u.userRepo.SetTwoFactor(user.ID, user.TwoFactorState); err != nil {
+				return entities.User{}, err
+			}
+			return user, nil
+		}
+	}
+	return entities.User{}, errInvalidTwoFactorCode
+}
+
+// generateRecoveryCodes создает резервные коды вида xxxxx-xxxxx и их bcrypt-хэши
+func generateRecoveryCodes() ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, 0, constants.RecoveryCodesCount)
+	hashes := make([]string, 0, constants.RecoveryCodesCount)
+	for len(codes) < constants.RecoveryCodesCount {
+		random := make([]byte, 8)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(random))[:recoveryCodeLength]
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+		hashes = append(hashes, string(hash))
+	}
+	return codes, hashes, nil
+}
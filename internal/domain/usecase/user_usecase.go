@@ -2,34 +2,80 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 	"marketplace/internal/domain/entities"
 	"marketplace/internal/domain/enums"
 	"marketplace/internal/domain/repository"
 	"marketplace/pkg/utils"
+	"net/url"
+	"strings"
 )
 
+// AccountSettings настройки учетных записей из конфигурации приложения
+type AccountSettings struct {
+	AppURL      string   // Адрес клиентского приложения для ссылок в письмах
+	AdminEmails []string // Email администраторов маркетплейса
+}
+
 type UserUseCase struct {
-	userRepo  repository.UserRepository
-	tokenRepo repository.JWTRepository
+	userRepo            repository.UserRepository
+	tokenRepo           repository.JWTRepository
+	actionTokenRepo     repository.ActionTokenRepository
+	notificationUseCase *NotificationUseCase
+	twoFactorUseCase    *TwoFactorUseCase
+	loginAttemptUseCase *LoginAttemptUseCase
+	settings            AccountSettings
 }
 
 // NewUserUseCase Конструктор для создания новой UserUseCase
-func NewUserUseCase(userRepo repository.UserRepository, tokenRepo repository.JWTRepository) *UserUseCase {
-	return &UserUseCase{userRepo: userRepo, tokenRepo: tokenRepo}
+func NewUserUseCase(
+	userRepo repository.UserRepository,
+	tokenRepo repository.JWTRepository,
+	actionTokenRepo repository.ActionTokenRepository,
+	notificationUseCase *NotificationUseCase,
+	twoFactorUseCase *TwoFactorUseCase,
+	loginAttemptUseCase *LoginAttemptUseCase,
+	settings AccountSettings,
+) *UserUseCase {
+	return &UserUseCase{
+		userRepo:            userRepo,
+		tokenRepo:           tokenRepo,
+		actionTokenRepo:     actionTokenRepo,
+		notificationUseCase: notificationUseCase,
+		twoFactorUseCase:    twoFactorUseCase,
+		loginAttemptUseCase: loginAttemptUseCase,
+		settings:            settings,
+	}
+}
+
+// actionLink данные писем со ссылкой на одноразовое действие
+type actionLink struct {
+	URL        string
+	ValidHours int
 }
 
-// Register Реализация метода Register
+// Register Реализация метода Register. ID пользователя назначает хранилище,
+// значение из запроса игнорируется. Права администратора выдаются только после
+// подтверждения email, иначе их получил бы любой, кто первым зарегистрирует адрес.
 func (u *UserUseCase) Register(user entities.User, ctx echo.Context) (*entities.Tokens, error) {
-	existingUser, err := u.userRepo.FindByEmail(user.Email)
-	if err == nil && existingUser.ID != 0 {
+	if _, err := u.userRepo.FindByEmail(user.Email); err == nil {
 		return nil, errors.New("user already exists")
 	}
 
+	user.ID = 0
+	user.IsAdmin = false
+	user.EmailVerified = false
+	user.TwoFactorEnabled = false
+
 	// Сохраняем пользователя в репозиторий
-	if err := u.userRepo.Create(user); err != nil {
+	user, err := u.userRepo.Create(user)
+	if err != nil {
 		return nil, err
 	}
+	u.sendActionLink(user.ID, enums.EmailVerificationToken, enums.WelcomeEmail, "/verify-email")
+
 	tokens, err := u.createTokens(user.ID, ctx)
 	if err != nil {
 		return nil, err
@@ -37,19 +83,67 @@ func (u *UserUseCase) Register(user entities.User, ctx echo.Context) (*entities.
 	return tokens, nil
 }
 
-// Login Реализация метода Login
-func (u *UserUseCase) Login(email, password string, ctx echo.Context) (*entities.Tokens, error) {
+// Login Реализация метода Login. Если у пользователя включена двухфакторная
+// аутентификация, вместо токенов возвращается MFAChallenge для второго шага.
+// После серии неудачных попыток возвращается LoginBlockedError.
+func (u *UserUseCase) Login(email, password string, ctx echo.Context) (*entities.Tokens, *entities.MFAChallenge, error) {
+	ip := ctx.RealIP()
+	// Неудачные попытки считаются по адресу без учета регистра, как он хранится
+	email = utils.NormalizeEmail(email)
+	if err := u.loginAttemptUseCase.Check(email, ip); err != nil {
+		return nil, nil, err
+	}
+
 	user, err := u.userRepo.FindByEmail(email)
 	if err != nil || user.Password != password { // Здесь должна быть логика хэширования пароля
-		return nil, errors.New("invalid credentials")
+		return nil, nil, u.loginFailed(email, ip, user.ID, errors.New("invalid credentials"))
 	}
 
+	if user.TwoFactorEnabled {
+		token, tokenID, err := utils.GenerateActionToken(user.ID, enums.MFAChallengeToken)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = u.actionTokenRepo.Save(enums.MFAChallengeToken, tokenID, user.ID); err != nil {
+			return nil, nil, err
+		}
+		return nil, &entities.MFAChallenge{MFARequired: true, MFAToken: token}, nil
+	}
+	u.loginAttemptUseCase.RegisterSuccess(email, ip)
+
 	tokens, err := u.createTokens(user.ID, ctx)
 	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, nil, nil
+}
+
+// CompleteMFALogin завершает вход кодом второго фактора. Токен второго шага
+// одноразовый даже при неверном коде, поэтому подбор требует повторного ввода пароля.
+func (u *UserUseCase) CompleteMFALogin(mfaToken, code string, ctx echo.Context) (*entities.Tokens, error) {
+	user, err := u.consumeActionToken(mfaToken, enums.MFAChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	ip := ctx.RealIP()
+	if err = u.loginAttemptUseCase.Check(user.Email, ip); err != nil {
 		return nil, err
 	}
+	if err = u.twoFactorUseCase.Verify(user.ID, code); err != nil {
+		return nil, u.loginFailed(user.Email, ip, user.ID, err)
+	}
+	u.loginAttemptUseCase.RegisterSuccess(user.Email, ip)
+	return u.createTokens(user.ID, ctx)
+}
 
-	return tokens, nil
+// UnlockAccount снимает блокировку входа по токену из письма
+func (u *UserUseCase) UnlockAccount(token string) error {
+	user, err := u.consumeActionToken(token, enums.AccountUnlockToken)
+	if err != nil {
+		return err
+	}
+	return u.loginAttemptUseCase.Unlock(user.Email)
 }
 
 // GetUserByID Реализация метода GetUserByID
@@ -62,6 +156,111 @@ func (u *UserUseCase) UpdateToken(id uint64) (entities.User, error) {
 	return u.userRepo.FindByID(id)
 }
 
+// RequestEmailVerification повторно отправляет пользователю ссылку подтверждения email
+func (u *UserUseCase) RequestEmailVerification(userID uint64) error {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return errors.New("email is already verified")
+	}
+	u.sendActionLink(user.ID, enums.EmailVerificationToken, enums.EmailVerificationEmail, "/verify-email")
+	return nil
+}
+
+// VerifyEmail подтверждает email пользователя по токену из письма
+func (u *UserUseCase) VerifyEmail(token string) error {
+	user, err := u.consumeActionToken(token, enums.EmailVerificationToken)
+	if err != nil {
+		return err
+	}
+	return u.markEmailVerified(user)
+}
+
+// RequestPasswordReset отправляет ссылку для сброса пароля. Для неизвестного email
+// ошибка не возвращается, чтобы по ответу нельзя было проверить наличие учетной записи.
+func (u *UserUseCase) RequestPasswordReset(email string) error {
+	user, err := u.userRepo.FindByEmail(email)
+	if err != nil {
+		return nil
+	}
+	u.sendActionLink(user.ID, enums.PasswordResetToken, enums.PasswordResetEmail, "/reset-password")
+	return nil
+}
+
+// ResetPassword устанавливает новый пароль по токену из письма и завершает все сессии пользователя
+func (u *UserUseCase) ResetPassword(token, password string) error {
+	user, err := u.consumeActionToken(token, enums.PasswordResetToken)
+	if err != nil {
+		return err
+	}
+
+	if err = u.userRepo.SetPassword(user.ID, password); err != nil {
+		return err
+	}
+	// Ссылка пришла на этот адрес, значит он принадлежит пользователю
+	if err = u.markEmailVerified(user); err != nil {
+		return err
+	}
+	if err = u.tokenRepo.RevokeSessions(user.ID); err != nil {
+		return err
+	}
+	// Новый пароль известен только владельцу, поэтому блокировка входа больше не нужна
+	if err = u.loginAttemptUseCase.Unlock(user.Email); err != nil {
+		return err
+	}
+	u.notificationUseCase.SendEmail(user.ID, enums.PasswordChangedEmail, nil)
+	return nil
+}
+
+// loginFailed учитывает неудачную попытку входа и возвращает ошибку для клиента. Если
+// учетная запись заблокирована, владельцу отправляется ссылка для разблокировки.
+// userID равен 0, если учетной записи с таким email нет.
+func (u *UserUseCase) loginFailed(email, ip string, userID uint64, err error) error {
+	blockErr := u.loginAttemptUseCase.RegisterFailure(email, ip)
+	if blockErr == nil {
+		return err
+	}
+	if userID != 0 {
+		u.sendActionLink(userID, enums.AccountUnlockToken, enums.AccountLockedEmail, "/unlock-account")
+	}
+	return blockErr
+}
+
+// sendActionLink выпускает одноразовый токен и отправляет пользователю письмо со ссылкой на path
+func (u *UserUseCase) sendActionLink(userID uint64, purpose enums.ActionToken, template enums.EmailTemplate, path string) {
+	token, tokenID, err := utils.GenerateActionToken(userID, purpose)
+	if err == nil {
+		err = u.actionTokenRepo.Save(purpose, tokenID, userID)
+	}
+	if err != nil {
+		logrus.Errorf("Failed to issue %s token for user %d: %v", purpose, userID, err)
+		return
+	}
+
+	u.notificationUseCase.SendEmail(userID, template, actionLink{
+		URL:        fmt.Sprintf("%s%s?token=%s", strings.TrimRight(u.settings.AppURL, "/"), path, url.QueryEscape(token)),
+		ValidHours: int(purpose.Duration().Hours()),
+	})
+}
+
+// consumeActionToken проверяет одноразовый токен, отмечает его использованным и возвращает пользователя
+func (u *UserUseCase) consumeActionToken(token string, purpose enums.ActionToken) (entities.User, error) {
+	userID, tokenID, err := utils.ValidateActionToken(token, purpose)
+	if err != nil {
+		return entities.User{}, err
+	}
+	storedUserID, err := u.actionTokenRepo.Consume(purpose, tokenID)
+	if err != nil {
+		return entities.User{}, err
+	}
+	if storedUserID != userID {
+		return entities.User{}, errors.New("token is invalid or has already been used")
+	}
+	return u.userRepo.FindByID(userID)
+}
+
 func (u *UserUseCase) createTokens(userId uint64, ctx echo.Context) (*entities.Tokens, error) {
 	{
 		accessToken, err := utils.GenerateToken(userId, enums.Access)
@@ -89,3 +288,25 @@ func (u *UserUseCase) createTokens(userId uint64, ctx echo.Context) (*entities.T
 		return &entities.Tokens{RefreshToken: refreshToken, AccessToken: accessToken}, nil
 	}
 }
+
+// markEmailVerified отмечает email подтвержденным и выдает права администратора,
+// если адрес входит в список администраторов
+func (u *UserUseCase) markEmailVerified(user entities.User) error {
+	if err := u.userRepo.SetEmailVerified(user.ID); err != nil {
+		return err
+	}
+	if u.isAdminEmail(user.Email) {
+		return u.userRepo.SetAdmin(user.ID, true)
+	}
+	return nil
+}
+
+// isAdminEmail проверяет, входит ли email в список администраторов
+func (u *UserUseCase) isAdminEmail(email string) bool {
+	for _, adminEmail := range u.settings.AdminEmails {
+		if strings.EqualFold(adminEmail, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"marketplace/internal/domain/entities"
+	"marketplace/internal/domain/enums"
+	"marketplace/internal/domain/repository"
+	"marketplace/pkg/constants"
+	"time"
+)
+
+// errWishlistNotFound возвращается и для чужих закрытых списков, чтобы они были неотличимы от несуществующих
+var errWishlistNotFound = errors.New("wishlist not found")
+
+// WishlistUseCase управляет списками желаний и уведомляет об изменениях товаров в них
+type WishlistUseCase struct {
+	wishlistRepo        repository.WishlistRepository
+	productRepo         repository.ProductRepository
+	notificationUseCase *NotificationUseCase
+	validator           *validator.Validate
+}
+
+// NewWishlistUseCase создает новый экземпляр WishlistUseCase
+func NewWishlistUseCase(
+	wishlistRepo repository.WishlistRepository,
+	productRepo repository.ProductRepository,
+	notificationUseCase *NotificationUseCase,
+	validate *validator.Validate,
+) *WishlistUseCase {
+	return &WishlistUseCase{
+		wishlistRepo:        wishlistRepo,
+		productRepo:         productRepo,
+		notificationUseCase: notificationUseCase,
+		validator:           validate,
+	}
+}
+
+// CreateWishlist создает пустой список желаний пользователя
+func (u *WishlistUseCase) CreateWishlist(userID uint64, wishlist entities.Wishlist) (entities.Wishlist, error) {
+	if wishlist.Visibility == "" {
+		wishlist.Visibility = enums.PrivateWishlist
+	}
+	if err := u.validator.Struct(wishlist); err != nil {
+		return entities.Wishlist{}, err
+	}
+
+	existing, err := u.wishlistRepo.FindAllByUser(userID)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+	if len(existing) >= constants.MaxWishlistsCount {
+		return entities.Wishlist{}, fmt.Errorf("wishlist limit of %d reached", constants.MaxWishlistsCount)
+	}
+
+	wishlist.UserID = userID
+	wishlist.Items = []entities.WishlistItem{}
+	wishlist.ShareToken = shareToken(wishlist.Visibility, "")
+	return u.wishlistRepo.Save(wishlist)
+}
+
+// UpdateWishlist изменяет название и видимость списка; при закрытии ссылки токен отзывается
+func (u *WishlistUseCase) UpdateWishlist(userID uint64, wishlist entities.Wishlist) (entities.Wishlist, error) {
+	if err := u.validator.Struct(wishlist); err != nil {
+		return entities.Wishlist{}, err
+	}
+
+	existing, err := u.ownWishlist(userID, wishlist.ID)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+	existing.Name = wishlist.Name
+	existing.Visibility = wishlist.Visibility
+	existing.ShareToken = shareToken(wishlist.Visibility, existing.ShareToken)
+	return u.wishlistRepo.Update(existing)
+}
+
+// DeleteWishlist удаляет список желаний пользователя
+func (u *WishlistUseCase) DeleteWishlist(userID, wishlistID uint64) error {
+	if _, err := u.ownWishlist(userID, wishlistID); err != nil {
+		return err
+	}
+	return u.wishlistRepo.Delete(wishlistID)
+}
+
+// GetWishlists возвращает все списки желаний пользователя
+func (u *WishlistUseCase) GetWishlists(userID uint64) ([]entities.Wishlist, error) {
+	return u.wishlistRepo.FindAllByUser(userID)
+}
+
+// GetWishlist возвращает список владельцу, а публичный список — любому пользователю
+func (u *WishlistUseCase) GetWishlist(userID, wishlistID uint64) (entities.Wishlist, error) {
+	wishlist, err := u.wishlistRepo.FindByID(wishlistID)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+	if wishlist.UserID == userID {
+		return wishlist, nil
+	}
+	if wishlist.Visibility != enums.PublicWishlist {
+		return entities.Wishlist{}, errWishlistNotFound
+	}
+	wishlist.ShareToken = ""
+	return wishlist, nil
+}
+
+// GetSharedWishlist возвращает список по токену ссылки
+func (u *WishlistUseCase) GetSharedWishlist(token string) (entities.Wishlist, error) {
+	wishlist, err := u.wishlistRepo.FindByShareToken(token)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+	if wishlist.Visibility != enums.SharedWishlist {
+		return entities.Wishlist{}, errWishlistNotFound
+	}
+	wishlist.ShareToken = ""
+	return wishlist, nil
+}
+
+// AddItem добавляет продукт или его вариант в список; повторное добавление ничего не меняет
+func (u *WishlistUseCase) AddItem(userID, wishlistID uint64, item entities.WishlistItem) (entities.Wishlist, error) {
+	if err := u.validator.Struct(item); err != nil {
+		return entities.Wishlist{}, err
+	}
+
+	wishlist, err := u.ownWishlist(userID, wishlistID)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+
+	product, err := u.productRepo.FindByID(item.ProductID)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+	if item.SKU != "" {
+		if _, ok := product.FindVariant(item.SKU); !ok {
+			return entities.Wishlist{}, fmt.Errorf("product %d has no variant %s", product.ID, item.SKU)
+		}
+	}
+
+	if wishlist.FindItem(item.ProductID, item.SKU) >= 0 {
+		return wishlist, nil
+	}
+	if len(wishlist.Items) >= constants.MaxWishlistItemsCount {
+		return entities.Wishlist{}, fmt.Errorf("wishlist item limit of %d reached", constants.MaxWishlistItemsCount)
+	}
+
+	item.AddedAt = time.Now()
+	wishlist.Items = append(wishlist.Items, item)
+	return u.wishlistRepo.Update(wishlist)
+}
+
+// RemoveItem удаляет продукт или его вариант из списка
+func (u *WishlistUseCase) RemoveItem(userID, wishlistID, productID uint64, sku string) (entities.Wishlist, error) {
+	wishlist, err := u.ownWishlist(userID, wishlistID)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+
+	index := wishlist.FindItem(productID, sku)
+	if index < 0 {
+		return entities.Wishlist{}, errors.New("item not found in wishlist")
+	}
+	wishlist.Items = append(wishlist.Items[:index], wishlist.Items[index+1:]...)
+	return u.wishlistRepo.Update(wishlist)
+}
+
+// NotifyProductChanged уведомляет владельцев списков желаний о снижении цены
+// или появлении в наличии продукта или его варианта.
+// Вызывается ProductUseCase после обновления продукта. Ошибка уведомления одного
+// пользователя не мешает уведомить остальных; все ошибки возвращаются вместе.
+func (u *WishlistUseCase) NotifyProductChanged(before, after entities.Product) error {
+	wishlists, err := u.wishlistRepo.FindAllByProduct(after.ID)
+	if err != nil {
+		return err
+	}
+
+	// Пользователь получает одно уведомление о событии, даже если товар в нескольких его списках
+	notified := make(map[string]bool)
+	var errs []error
+	for _, wishlist := range wishlists {
+		for _, item := range wishlist.Items {
+			if item.ProductID != after.ID {
+				continue
+			}
+			for _, notification := range productChangeNotifications(before, after, item.SKU) {
+				key := fmt.Sprintf("%d:%s:%s", wishlist.UserID, item.SKU, notification.Type)
+				if notified[key] {
+					continue
+				}
+				notified[key] = true
+
+				notification.UserID = wishlist.UserID
+				if err := u.notificationUseCase.Notify(notification); err != nil {
+					errs = append(errs, fmt.Errorf("failed to notify user %d: %w", wishlist.UserID, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ownWishlist возвращает список, если он принадлежит пользователю
+func (u *WishlistUseCase) ownWishlist(userID, wishlistID uint64) (entities.Wishlist, error) {
+	wishlist, err := u.wishlistRepo.FindByID(wishlistID)
+	if err != nil {
+		return entities.Wishlist{}, err
+	}
+	if wishlist.UserID != userID {
+		return entities.Wishlist{}, errWishlistNotFound
+	}
+	return wishlist, nil
+}
+
+// shareToken возвращает токен ссылки для видимости shared, сохраняя уже выданный
+func shareToken(visibility enums.WishlistVisibility, current string) string {
+	if visibility != enums.SharedWishlist {
+		return ""
+	}
+	if current != "" {
+		return current
+	}
+	return uuid.New().String()
+}
+
+// productChangeNotifications сравнивает цену и наличие позиции списка до и после изменения продукта
+func productChangeNotifications(before, after entities.Product, sku string) []entities.Notification {
+	oldPrice, oldInStock, oldFound := wishlistItemState(before, sku)
+	newPrice, newInStock, newFound := wishlistItemState(after, sku)
+	if !oldFound || !newFound {
+		return nil
+	}
+
+	var notifications []entities.Notification
+	if newPrice < oldPrice {
+		notifications = append(notifications, entities.Notification{
+			Type:      enums.PriceDropNotification,
+			ProductID: after.ID,
+			SKU:       sku,
+			OldPrice:  oldPrice,
+			NewPrice:  newPrice,
+		})
+	}
+	if !oldInStock && newInStock {
+		notifications = append(notifications, entities.Notification{
+			Type:      enums.BackInStockNotification,
+			ProductID: after.ID,
+			SKU:       sku,
+			NewPrice:  newPrice,
+		})
+	}
+	return notifications
+}
+
+// wishlistItemState возвращает цену и наличие варианта или продукта целиком.
+// Продукт без вариантов остатков не ведет и считается доступным.
+func wishlistItemState(product entities.Product, sku string) (float64, bool, bool) {
+	if sku != "" {
+		variant, ok := product.FindVariant(sku)
+		if !ok {
+			return 0, false, false
+		}
+		return variant.EffectivePrice(product), variant.Stock > 0, true
+	}
+
+	inStock := len(product.Variants) == 0
+	for _, variant := range product.Variants {
+		if variant.Stock > 0 {
+			inStock = true
+			break
+		}
+	}
+	return product.Price, inStock, true
+}
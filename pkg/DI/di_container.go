@@ -1,16 +1,35 @@
 package DI
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
 	"go.uber.org/dig"
+	"io/fs"
+	"marketplace/config"
 	"marketplace/delivery/handlers"
 	"marketplace/delivery/middleware"
+	"marketplace/internal/data/events"
+	"marketplace/internal/data/invoice"
+	"marketplace/internal/data/mail"
+	"marketplace/internal/data/moderation"
+	"marketplace/internal/data/payment"
+	"marketplace/internal/data/ratelimit"
 	"marketplace/internal/data/repository"
+	"marketplace/internal/data/storage"
+	"marketplace/internal/data/tax"
+	domainRepository "marketplace/internal/domain/repository"
 	"marketplace/internal/domain/usecase"
+	"marketplace/pkg/constants"
+	"marketplace/pkg/lifecycle"
 	"marketplace/pkg/utils"
-	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 var container = dig.New()
@@ -19,26 +38,138 @@ func Container() *dig.Container {
 	return container
 }
 
+// RegisterConfig добавляет в контейнер настройки приложения и применяет те из них,
+// которые хранятся на уровне пакетов
+func RegisterConfig(container *dig.Container, cfg *config.Config) error {
+	if err := container.Provide(func() *config.Config { return cfg }); err != nil {
+		return err
+	}
+	utils.SetJWTSecret(cfg.JWT.Secret)
+	return nil
+}
+
+// RegisterLifecycle добавляет в контейнер Lifecycle, в котором компоненты при создании
+// регистрируют действия при запуске и остановке приложения
+func RegisterLifecycle(container *dig.Container, lc *lifecycle.Lifecycle) error {
+	return container.Provide(func() *lifecycle.Lifecycle { return lc })
+}
+
 func RegisterDatabases(container *dig.Container) error {
 	if err := container.Provide(registerRedisClient); err != nil {
 		return err
 	}
+	if err := container.Provide(registerBlobStorage); err != nil {
+		return err
+	}
+	if err := container.Provide(registerPrivateBlobStorage); err != nil {
+		return err
+	}
+	if err := container.Provide(registerMailer); err != nil {
+		return err
+	}
+	if err := container.Provide(registerPaymentProvider); err != nil {
+		return err
+	}
 	return nil
 }
 
-func registerRedisClient() (*redis.Client, error) {
+// registerRedisClient создает клиент Redis. Подключение проверяется при запуске
+// приложения, а закрывается после остановки всех зависящих от него компонентов.
+func registerRedisClient(cfg *config.Config, lc *lifecycle.Lifecycle) *redis.Client {
 	redisClient := redis.NewClient(
 		&redis.Options{
-			Addr:     os.Getenv("REDIS_ADDR"),
-			Password: "",
-			DB:       0,
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
 		},
 	)
-	pong, err := redisClient.Ping(redisClient.Context()).Result()
-	if pong != "PONG" || err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %v", err)
+	lc.Append(lifecycle.Hook{
+		Name: "redis",
+		OnStart: func(ctx context.Context) error {
+			pong, err := redisClient.Ping(ctx).Result()
+			if pong != "PONG" || err != nil {
+				return fmt.Errorf("failed to connect to Redis: %v", err)
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return redisClient.Close()
+		},
+	})
+	return redisClient
+}
+
+// registerBlobStorage выбирает хранилище файлов по storage.backend (local или s3)
+func registerBlobStorage(cfg *config.Config) (domainRepository.BlobStorage, error) {
+	switch cfg.Storage.Backend {
+	case "local":
+		return storage.NewLocalBlobStorage(cfg.Storage.LocalDir, localStorageURL)
+	case "s3":
+		return storage.NewS3BlobStorage(storage.S3Options{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			Bucket:    cfg.Storage.S3.Bucket,
+			UseSSL:    cfg.Storage.S3.UseSSL,
+			PublicURL: cfg.Storage.S3.PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob storage: %s", cfg.Storage.Backend)
+	}
+}
+
+// registerPrivateBlobStorage создает хранилище закрытых файлов: отдельный каталог, который
+// не раздается по /media, или отдельный бакет S3 без публичного доступа
+func registerPrivateBlobStorage(cfg *config.Config) (domainRepository.PrivateBlobStorage, error) {
+	switch cfg.Storage.Backend {
+	case "local":
+		return storage.NewLocalBlobStorage(cfg.Storage.PrivateLocalDir, "")
+	case "s3":
+		bucket := cfg.Storage.S3.PrivateBucket
+		if bucket == "" {
+			bucket = cfg.Storage.S3.Bucket + "-private"
+		}
+		return storage.NewS3BlobStorage(storage.S3Options{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			Bucket:    bucket,
+			UseSSL:    cfg.Storage.S3.UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob storage: %s", cfg.Storage.Backend)
+	}
+}
+
+// localStorageURL путь, по которому раздаются файлы локального хранилища
+const localStorageURL = "/media"
+
+// registerMailer выбирает способ отправки писем по mail.mailer (file или smtp)
+func registerMailer(cfg *config.Config) (domainRepository.Mailer, error) {
+	switch cfg.Mail.Mailer {
+	case "file":
+		return mail.NewFileMailer(cfg.Mail.DumpDir, cfg.Mail.From)
+	case "smtp":
+		return mail.NewSMTPMailer(mail.SMTPOptions{
+			Host:     cfg.Mail.SMTP.Host,
+			Port:     cfg.Mail.SMTP.Port,
+			Username: cfg.Mail.SMTP.Username,
+			Password: cfg.Mail.SMTP.Password,
+			From:     cfg.Mail.From,
+		})
+	default:
+		return nil, fmt.Errorf("unknown mailer: %s", cfg.Mail.Mailer)
+	}
+}
+
+// registerPaymentProvider выбирает платежного провайдера по payments.provider
+func registerPaymentProvider(cfg *config.Config) (domainRepository.PaymentProvider, error) {
+	switch cfg.Payments.Provider {
+	case "sandbox":
+		return payment.NewSandboxProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider: %s", cfg.Payments.Provider)
 	}
-	return redisClient, nil
 }
 
 func RegisterDependencies(container *dig.Container) error {
@@ -56,14 +187,98 @@ func RegisterDependencies(container *dig.Container) error {
 	if err := container.Provide(repository.NewProductRepository); err != nil {
 		return err
 	}
+	if err := container.Provide(repository.NewInvoiceRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewPaymentRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewReturnRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(invoice.NewPDFRenderer); err != nil {
+		return err
+	}
 	if err := container.Provide(repository.NewStoreRepository); err != nil {
 		return err
 	}
 	if err := container.Provide(repository.NewRedisJWTRepository); err != nil {
 		return err
 	}
+	if err := container.Provide(repository.NewRedisActionTokenRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewRedisLoginAttemptRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewRedisIdempotencyRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewExchangeRateRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewCategoryRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewReviewRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewCouponRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewPromotionRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewShippingProfileRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewAddressRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewTaxRateRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(tax.NewRateTableCalculator); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewLedgerRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewCommissionRuleRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewWishlistRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewNotificationRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewNotificationPreferencesRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewQuestionRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(registerContentModerator); err != nil {
+		return err
+	}
+	if err := container.Provide(repository.NewConversationRepository); err != nil {
+		return err
+	}
+	if err := container.Provide(registerEventBus); err != nil {
+		return err
+	}
 
 	// Регистрация use cases
+	if err := container.Provide(registerAccountSettings); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewTwoFactorUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewLoginAttemptUseCase); err != nil {
+		return err
+	}
 	if err := container.Provide(usecase.NewUserUseCase); err != nil {
 		return err
 	}
@@ -73,6 +288,66 @@ func RegisterDependencies(container *dig.Container) error {
 	if err := container.Provide(usecase.NewStoreUseCase); err != nil {
 		return err
 	}
+	if err := container.Provide(usecase.NewCurrencyUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewCategoryUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewProductImageUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewReviewUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewPromotionUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewPricingUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewAddressUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewShippingUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewTaxUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewLedgerUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewInvoiceUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewPaymentUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewReturnUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(mail.Templates); err != nil {
+		return err
+	}
+	if err := container.Provide(registerEmailUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewNotificationUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewWishlistUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewQuestionUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewConversationUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewEventUseCase); err != nil {
+		return err
+	}
 
 	// Регистрация обработчиков
 	if err := container.Provide(handlers.NewUserHandler); err != nil {
@@ -84,21 +359,270 @@ func RegisterDependencies(container *dig.Container) error {
 	if err := container.Provide(handlers.NewStoreHandler); err != nil {
 		return err
 	}
+	if err := container.Provide(handlers.NewCurrencyHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewCategoryHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewProductImageHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewReviewHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewPromotionHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewPricingHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewShippingHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewAddressHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewTaxHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewLedgerHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewInvoiceHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewPaymentHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewReturnHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewWishlistHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewTwoFactorHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewNotificationHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewQuestionHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(handlers.NewConversationHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(registerEventHandler); err != nil {
+		return err
+	}
+
+	// Регистрация middleware с зависимостями
+	if err := container.Provide(registerAccessMiddleware); err != nil {
+		return err
+	}
+	if err := container.Provide(registerRateLimiter); err != nil {
+		return err
+	}
+	if err := container.Provide(middleware.NewRateLimitMiddleware); err != nil {
+		return err
+	}
+	if err := container.Provide(middleware.NewIdempotencyMiddleware); err != nil {
+		return err
+	}
+
+	// Загрузка курсов валют из локального файла
+	if err := container.Invoke(loadExchangeRates); err != nil {
+		return err
+	}
+
+	// Периодическое формирование выплат продавцам
+	if err := container.Invoke(startPayoutSchedule); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// registerAccountSettings передает UserUseCase настройки учетных записей
+func registerAccountSettings(cfg *config.Config) usecase.AccountSettings {
+	return usecase.AccountSettings{AppURL: cfg.App.URL, AdminEmails: cfg.App.AdminEmails}
+}
+
+// registerAccessMiddleware создает AccessMiddleware. security.require_verified_sellers закрывает
+// действия продавца для пользователей с неподтвержденным email, security.require_2fa_roles
+// требует для перечисленных ролей двухфакторную аутентификацию
+func registerAccessMiddleware(
+	userRepo domainRepository.UserRepository,
+	tokenRepo domainRepository.JWTRepository,
+	cfg *config.Config,
+) *middleware.AccessMiddleware {
+	policy := middleware.AccessPolicy{RequireVerifiedSellers: cfg.Security.RequireVerifiedSellers}
+	for _, role := range cfg.Security.Require2FARoles {
+		switch role {
+		case "admin":
+			policy.RequireAdminTwoFactor = true
+		case "seller":
+			policy.RequireSellerTwoFactor = true
+		}
+	}
+	return middleware.NewAccessMiddleware(userRepo, tokenRepo, policy)
+}
+
+// registerRateLimiter создает ограничитель частоты запросов на Redis, общий для всех
+// экземпляров сервера. Пока Redis недоступен, запросы считаются в памяти процесса.
+func registerRateLimiter(redisClient *redis.Client) domainRepository.RateLimiter {
+	return ratelimit.NewFallbackRateLimiter(
+		ratelimit.NewRedisRateLimiter(redisClient),
+		ratelimit.NewMemoryRateLimiter(),
+	)
+}
+
+// rateLimitPolicy возвращает политику ограничения частоты с лимитом по умолчанию, который
+// можно переопределить в rate_limits конфигурации. Лимит 0 отключает ограничение.
+func rateLimitPolicy(cfg *config.Config, name string, limit int, window time.Duration) middleware.RateLimitPolicy {
+	if override, ok := cfg.RateLimits[name]; ok {
+		limit, window = override.Limit, override.Window
+	}
+	return middleware.RateLimitPolicy{Name: name, Limit: limit, Window: window}
+}
+
+// checkRateLimitPolicies проверяет, что rate_limits переопределяет только объявленные политики.
+// Иначе опечатка в имени молча оставила бы лимит по умолчанию.
+func checkRateLimitPolicies(cfg *config.Config, policies ...middleware.RateLimitPolicy) error {
+	declared := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		declared[policy.Name] = true
+	}
+	var unknown []string
+	for name := range cfg.RateLimits {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("invalid configuration: rate_limits (RATE_LIMIT_*) has unknown policies: %s", strings.Join(unknown, ", "))
+}
+
+// registerContentModerator создает модератор по стоп-словам из moderation.stop_words
+func registerContentModerator(cfg *config.Config) domainRepository.ContentModerator {
+	return moderation.NewStopWordModerator(cfg.Moderation.StopWords)
+}
+
+// registerEventBus создает шину событий на Redis, которая отключается от рассылки при остановке
+func registerEventBus(redisClient *redis.Client, lc *lifecycle.Lifecycle) domainRepository.EventBus {
+	eventBus := events.NewRedisEventBus(redisClient)
+	lc.Append(lifecycle.Hook{
+		Name: "event bus",
+		OnStop: func(ctx context.Context) error {
+			return eventBus.Close()
+		},
+	})
+	return eventBus
+}
+
+// registerEventHandler создает EventHandler с origin из events.ws_allowed_origins.
+// Открытые потоки событий закрываются в начале остановки, иначе HTTP-сервер ждал бы их до таймаута.
+func registerEventHandler(eventUseCase *usecase.EventUseCase, cfg *config.Config, lc *lifecycle.Lifecycle) *handlers.EventHandler {
+	return handlers.NewEventHandler(eventUseCase, cfg.Events.WSAllowedOrigins, lc.Stopping())
+}
+
+// registerEmailUseCase создает EmailUseCase; при остановке дожидается отправки начатых писем
+func registerEmailUseCase(mailer domainRepository.Mailer, templateFS fs.FS, lc *lifecycle.Lifecycle) (*usecase.EmailUseCase, error) {
+	emailUseCase, err := usecase.NewEmailUseCase(mailer, templateFS)
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(lifecycle.Hook{
+		Name:   "email delivery",
+		OnStop: emailUseCase.Shutdown,
+	})
+	return emailUseCase, nil
+}
+
+func loadExchangeRates(currencyUseCase *usecase.CurrencyUseCase, cfg *config.Config) error {
+	if cfg.Ledger.ExchangeRatesFile == "" {
+		return nil
+	}
+	return currencyUseCase.LoadFromFile(cfg.Ledger.ExchangeRatesFile)
+}
+
+// startPayoutSchedule запускает формирование пакетов выплат с интервалом ledger.payout_interval.
+// При остановке начатое формирование пакета завершается, новые не начинаются.
+func startPayoutSchedule(ledgerUseCase *usecase.LedgerUseCase, cfg *config.Config, lc *lifecycle.Lifecycle) {
+	interval := cfg.Ledger.PayoutInterval
+	if interval == 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	lc.Append(lifecycle.Hook{
+		Name: "payout schedule",
+		OnStart: func(ctx context.Context) error {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						if _, err := ledgerUseCase.CreatePayoutBatch(); err != nil {
+							logrus.Errorf("Failed to create payout batch: %v", err)
+						}
+					case <-stop:
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(stop)
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return fmt.Errorf("payout batch is still being created: %v", ctx.Err())
+			}
+		},
+	})
+}
+
 func RegisterMiddleware(container *dig.Container, e *echo.Echo) error {
 	// Используем логгер из контейнера
 	var httpLogger *middleware.AppLoggers
-	if err := container.Invoke(func(logger *middleware.AppLoggers) {
+	var cfg *config.Config
+	if err := container.Invoke(func(logger *middleware.AppLoggers, c *config.Config) {
 		httpLogger = logger
+		cfg = c
 	}); err != nil {
 		return fmt.Errorf("failed to invoke logger: %w", err)
 	}
 
+	// IP клиента для ограничения частоты запросов и защиты входа
+	ipExtractor, err := registerIPExtractor(cfg)
+	if err != nil {
+		return err
+	}
+	e.IPExtractor = ipExtractor
+
+	// Паника в обработчике возвращает клиенту 500 и попадает в лог вместо обрыва соединения
+	e.Use(echoMiddleware.Recover())
+	// Слишком большое тело запроса отклоняется с 413 до чтения обработчиком
+	e.Use(middleware.BodyLimit(constants.MaxRequestBodySize))
+
 	// Добавляем midleware для логирования в зависимости от окружения
-	if os.Getenv("APP_ENV") == "Dev" {
+	if cfg.App.IsDev() {
 		e.Use(httpLogger.LoggingRequestMiddleware)
 		e.Use(httpLogger.LoggingResponseMiddleware)
 	}
@@ -106,41 +630,290 @@ func RegisterMiddleware(container *dig.Container, e *echo.Echo) error {
 	return nil
 }
 
+// registerIPExtractor определяет IP клиента. Без http.trusted_proxies используется адрес
+// соединения: иначе клиент мог бы подставлять любой IP в X-Forwarded-For и обходить
+// ограничения по IP. За доверенными прокси IP берется из X-Forwarded-For.
+func registerIPExtractor(cfg *config.Config) (echo.IPExtractor, error) {
+	proxies, err := cfg.HTTP.TrustedProxyNets()
+	if err != nil {
+		return nil, err
+	}
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range proxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
 func RegisterRoutes(container *dig.Container, e *echo.Echo) error {
 	// Инициализация HTTP-хэндлеров
 	var userHandler *handlers.UserHandler
 	var productHandler *handlers.ProductHandler
 	var storeHandler *handlers.StoreHandler
+	var currencyHandler *handlers.CurrencyHandler
+	var categoryHandler *handlers.CategoryHandler
+	var productImageHandler *handlers.ProductImageHandler
+	var reviewHandler *handlers.ReviewHandler
+	var promotionHandler *handlers.PromotionHandler
+	var pricingHandler *handlers.PricingHandler
+	var shippingHandler *handlers.ShippingHandler
+	var addressHandler *handlers.AddressHandler
+	var taxHandler *handlers.TaxHandler
+	var ledgerHandler *handlers.LedgerHandler
+	var invoiceHandler *handlers.InvoiceHandler
+	var paymentHandler *handlers.PaymentHandler
+	var returnHandler *handlers.ReturnHandler
+	var wishlistHandler *handlers.WishlistHandler
+	var notificationHandler *handlers.NotificationHandler
+	var questionHandler *handlers.QuestionHandler
+	var conversationHandler *handlers.ConversationHandler
+	var eventHandler *handlers.EventHandler
+	var twoFactorHandler *handlers.TwoFactorHandler
+	var accessMiddleware *middleware.AccessMiddleware
+	var rateLimitMiddleware *middleware.RateLimitMiddleware
+	var idempotencyMiddleware *middleware.IdempotencyMiddleware
+	var cfg *config.Config
 
 	// Получаем хэндлеры через контейнер
-	if err := container.Invoke(func(uh *handlers.UserHandler, ph *handlers.ProductHandler, sh *handlers.StoreHandler) {
+	if err := container.Invoke(func(
+		uh *handlers.UserHandler,
+		ph *handlers.ProductHandler,
+		sh *handlers.StoreHandler,
+		ch *handlers.CurrencyHandler,
+		cth *handlers.CategoryHandler,
+		pih *handlers.ProductImageHandler,
+		rh *handlers.ReviewHandler,
+		prh *handlers.PromotionHandler,
+		pch *handlers.PricingHandler,
+		shh *handlers.ShippingHandler,
+		adh *handlers.AddressHandler,
+		th *handlers.TaxHandler,
+		lh *handlers.LedgerHandler,
+		ih *handlers.InvoiceHandler,
+		pmh *handlers.PaymentHandler,
+		rth *handlers.ReturnHandler,
+		wh *handlers.WishlistHandler,
+		nh *handlers.NotificationHandler,
+		qh *handlers.QuestionHandler,
+		cvh *handlers.ConversationHandler,
+		evh *handlers.EventHandler,
+		tfh *handlers.TwoFactorHandler,
+		am *middleware.AccessMiddleware,
+		rlm *middleware.RateLimitMiddleware,
+		idm *middleware.IdempotencyMiddleware,
+		c *config.Config,
+	) {
 		userHandler = uh
 		productHandler = ph
 		storeHandler = sh
+		currencyHandler = ch
+		categoryHandler = cth
+		productImageHandler = pih
+		reviewHandler = rh
+		promotionHandler = prh
+		pricingHandler = pch
+		shippingHandler = shh
+		addressHandler = adh
+		taxHandler = th
+		ledgerHandler = lh
+		invoiceHandler = ih
+		paymentHandler = pmh
+		returnHandler = rth
+		wishlistHandler = wh
+		notificationHandler = nh
+		questionHandler = qh
+		conversationHandler = cvh
+		eventHandler = evh
+		twoFactorHandler = tfh
+		accessMiddleware = am
+		rateLimitMiddleware = rlm
+		idempotencyMiddleware = idm
+		cfg = c
 	}); err != nil {
 		fmt.Printf("Failed to invoke handlers: %v\n", err)
 		return err
 	}
 
+	// Раздача файлов локального хранилища
+	if cfg.Storage.Backend == "local" {
+		e.Static(localStorageURL, cfg.Storage.LocalDir)
+	}
+
+	// Политики ограничения частоты запросов. Аутентифицированные запросы считаются
+	// по пользователю, остальные — по IP-адресу. Лимиты переопределяются в rate_limits.
+	rateLimit := rateLimitMiddleware.Limit
+	apiLimit := rateLimitPolicy(cfg, "api", 300, time.Minute)
+	publicLimit := rateLimitPolicy(cfg, "public", 60, time.Minute)
+	registerLimit := rateLimitPolicy(cfg, "register", 5, time.Hour)
+	loginLimit := rateLimitPolicy(cfg, "login", 10, time.Minute)
+	emailLimit := rateLimitPolicy(cfg, "email", 5, time.Hour)
+	uploadLimit := rateLimitPolicy(cfg, "upload", 30, time.Minute)
+	streamLimit := rateLimitPolicy(cfg, "stream", 10, time.Minute)
+	messageLimit := rateLimitPolicy(cfg, "message", 20, time.Minute)
+	if err := checkRateLimitPolicies(cfg, apiLimit, publicLimit, registerLimit, loginLimit, emailLimit, uploadLimit, streamLimit, messageLimit); err != nil {
+		return err
+	}
+
+	// POST-запросы с заголовком Idempotency-Key можно безопасно повторять
 	authorizedScope := e.Group("")
-	authorizedScope.Use(middleware.JWTMiddleware)
+	authorizedScope.Use(
+		middleware.JWTMiddleware,
+		accessMiddleware.ActiveSession,
+		rateLimit(apiLimit),
+		idempotencyMiddleware.Idempotent,
+	)
 
 	// Регистрация маршрутов для пользователей
-	e.POST("/users", userHandler.Register)
-	e.POST("/users/login", userHandler.Login)
+	e.POST("/users", userHandler.Register, rateLimit(registerLimit))
+	e.POST("/users/login", userHandler.Login, rateLimit(loginLimit))
+	e.POST("/users/login/2fa", userHandler.CompleteMFALogin, rateLimit(loginLimit))
+	e.POST("/users/verify-email", userHandler.VerifyEmail, rateLimit(publicLimit))
+	e.POST("/users/password-reset/request", userHandler.RequestPasswordReset, rateLimit(emailLimit))
+	e.POST("/users/password-reset", userHandler.ResetPassword, rateLimit(publicLimit))
+	e.POST("/users/unlock", userHandler.UnlockAccount, rateLimit(publicLimit))
+	authorizedScope.POST("/users/me/verify-email/request", userHandler.RequestEmailVerification, rateLimit(emailLimit))
+	authorizedScope.POST("/users/me/2fa/setup", twoFactorHandler.Setup)
+	authorizedScope.POST("/users/me/2fa/enable", twoFactorHandler.Enable)
+	authorizedScope.POST("/users/me/2fa/disable", twoFactorHandler.Disable)
+	authorizedScope.POST("/users/me/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+
+	// Регистрация маршрутов для адресной книги
+	authorizedScope.GET("/users/me/addresses", addressHandler.GetAddresses)
+	authorizedScope.POST("/users/me/addresses", addressHandler.CreateAddress)
+	authorizedScope.GET("/users/me/addresses/:id", addressHandler.GetAddress)
+	authorizedScope.PUT("/users/me/addresses/:id", addressHandler.UpdateAddress)
+	authorizedScope.DELETE("/users/me/addresses/:id", addressHandler.DeleteAddress)
+
+	// Регистрация маршрутов для списков желаний и уведомлений
+	e.GET("/wishlists/shared/:token", wishlistHandler.GetSharedWishlist, rateLimit(publicLimit))
+	authorizedScope.GET("/users/me/wishlists", wishlistHandler.GetWishlists)
+	authorizedScope.POST("/users/me/wishlists", wishlistHandler.CreateWishlist)
+	authorizedScope.GET("/wishlists/:id", wishlistHandler.GetWishlist)
+	authorizedScope.PUT("/wishlists/:id", wishlistHandler.UpdateWishlist)
+	authorizedScope.DELETE("/wishlists/:id", wishlistHandler.DeleteWishlist)
+	authorizedScope.POST("/wishlists/:id/items", wishlistHandler.AddWishlistItem)
+	authorizedScope.DELETE("/wishlists/:id/items/:product_id", wishlistHandler.RemoveWishlistItem)
+	authorizedScope.GET("/users/me/notifications", notificationHandler.GetNotifications)
+	authorizedScope.POST("/users/me/notifications/:id/read", notificationHandler.MarkNotificationRead)
+	authorizedScope.GET("/users/me/notification-preferences", notificationHandler.GetPreferences)
+	authorizedScope.PUT("/users/me/notification-preferences", notificationHandler.UpdatePreferences)
 
 	// Регистрация маршрутов для продуктов
-	authorizedScope.POST("/products", productHandler.CreateProduct)
+	authorizedScope.POST("/products", productHandler.CreateProduct, accessMiddleware.SellerAccess)
 	authorizedScope.GET("/products/:id", productHandler.GetProductByID)
-	authorizedScope.PUT("/products/:id", productHandler.UpdateProduct)
-	authorizedScope.DELETE("/products/:id", productHandler.DeleteProduct)
+	authorizedScope.PUT("/products/:id", productHandler.UpdateProduct, accessMiddleware.SellerAccess)
+	authorizedScope.DELETE("/products/:id", productHandler.DeleteProduct, accessMiddleware.SellerAccess)
 	authorizedScope.GET("/stores/:store_id/products", productHandler.GetProductsByStore)
+	authorizedScope.POST("/products/:id/images", productImageHandler.UploadImage, accessMiddleware.SellerAccess, rateLimit(uploadLimit))
+	authorizedScope.DELETE("/products/:id/images/:image_id", productImageHandler.DeleteImage, accessMiddleware.SellerAccess)
+
+	// Регистрация маршрутов для отзывов
+	authorizedScope.POST("/products/:id/reviews", reviewHandler.CreateReview)
+	authorizedScope.GET("/products/:id/reviews", reviewHandler.GetProductReviews)
+	authorizedScope.POST("/reviews/:id/reply", reviewHandler.ReplyToReview)
+	authorizedScope.POST("/reviews/:id/photos", reviewHandler.AddReviewPhoto, rateLimit(uploadLimit))
+
+	// Регистрация маршрутов для вопросов и ответов о продуктах
+	authorizedScope.POST("/products/:id/questions", questionHandler.AskQuestion)
+	authorizedScope.GET("/products/:id/questions", questionHandler.GetProductQuestions)
+	authorizedScope.POST("/questions/:id/answers", questionHandler.AnswerQuestion)
+	authorizedScope.GET("/questions/:id/answers", questionHandler.GetAnswers)
+	authorizedScope.POST("/answers/:id/upvote", questionHandler.UpvoteAnswer)
+	authorizedScope.DELETE("/answers/:id/upvote", questionHandler.RemoveUpvote)
+	authorizedScope.PUT("/questions/:id/moderation", questionHandler.ModerateQuestion, accessMiddleware.AdminOnly)
+	authorizedScope.PUT("/answers/:id/moderation", questionHandler.ModerateAnswer, accessMiddleware.AdminOnly)
+	authorizedScope.GET("/moderation/questions", questionHandler.GetModerationQuestions, accessMiddleware.AdminOnly)
+	authorizedScope.GET("/moderation/answers", questionHandler.GetModerationAnswers, accessMiddleware.AdminOnly)
 
 	// Регистрация маршрутов для магазинов
-	authorizedScope.POST("/stores", storeHandler.CreateStore)
+	authorizedScope.POST("/stores", storeHandler.CreateStore, accessMiddleware.SellerAccess)
 	authorizedScope.GET("/stores/:id", storeHandler.GetStoreByID)
-	authorizedScope.PUT("/stores/:id", storeHandler.UpdateStore)
-	authorizedScope.DELETE("/stores/:id", storeHandler.DeleteStore)
+	authorizedScope.PUT("/stores/:id", storeHandler.UpdateStore, accessMiddleware.SellerAccess)
+	authorizedScope.DELETE("/stores/:id", storeHandler.DeleteStore, accessMiddleware.SellerAccess)
 	authorizedScope.GET("/stores", storeHandler.GetAllStores)
+
+	// Подключение к событиям реального времени
+	e.GET("/ws", eventHandler.UserEvents, middleware.QueryTokenJWTMiddleware, accessMiddleware.ActiveSession, rateLimit(streamLimit))
+	e.GET("/stores/:id/events", eventHandler.StoreEvents, middleware.QueryTokenJWTMiddleware, accessMiddleware.ActiveSession, rateLimit(streamLimit))
+
+	// Регистрация маршрутов для переписки с магазинами
+	authorizedScope.POST("/conversations", conversationHandler.StartConversation, rateLimit(messageLimit))
+	authorizedScope.GET("/conversations", conversationHandler.GetConversations)
+	authorizedScope.GET("/conversations/unread", conversationHandler.GetUnreadCount)
+	authorizedScope.GET("/conversations/:id/messages", conversationHandler.GetMessages)
+	authorizedScope.POST("/conversations/:id/messages", conversationHandler.SendMessage, rateLimit(messageLimit))
+	authorizedScope.POST("/conversations/:id/attachments", conversationHandler.SendAttachment, rateLimit(messageLimit), rateLimit(uploadLimit))
+	authorizedScope.GET("/conversations/:id/attachments/:name", conversationHandler.GetAttachment)
+	authorizedScope.POST("/conversations/:id/read", conversationHandler.MarkRead)
+
+	// Регистрация маршрутов для промокодов и акций
+	authorizedScope.POST("/stores/:id/coupons", promotionHandler.CreateCoupon, accessMiddleware.SellerAccess)
+	authorizedScope.GET("/stores/:id/coupons", promotionHandler.GetStoreCoupons)
+	authorizedScope.DELETE("/coupons/:id", promotionHandler.DeleteCoupon, accessMiddleware.SellerAccess)
+	authorizedScope.POST("/stores/:id/promotions", promotionHandler.CreatePromotion, accessMiddleware.SellerAccess)
+	authorizedScope.GET("/stores/:id/promotions", promotionHandler.GetStorePromotions)
+	authorizedScope.DELETE("/promotions/:id", promotionHandler.DeletePromotion, accessMiddleware.SellerAccess)
+
+	// Регистрация маршрутов для расчета стоимости
+	authorizedScope.POST("/pricing/quote", pricingHandler.Quote)
+
+	// Регистрация маршрутов для доставки
+	authorizedScope.PUT("/stores/:id/shipping-profile", shippingHandler.SaveShippingProfile, accessMiddleware.SellerAccess)
+	authorizedScope.GET("/stores/:id/shipping-profile", shippingHandler.GetShippingProfile)
+	authorizedScope.POST("/shipping/quote", shippingHandler.Quote)
+
+	// Регистрация маршрутов для курсов валют
+	authorizedScope.GET("/exchange-rates", currencyHandler.GetExchangeRates)
+	authorizedScope.POST("/exchange-rates", currencyHandler.AddExchangeRate, accessMiddleware.AdminOnly)
+
+	// Регистрация маршрутов для налоговых ставок
+	authorizedScope.GET("/tax-rates", taxHandler.GetTaxRates)
+	authorizedScope.POST("/tax-rates", taxHandler.CreateTaxRate, accessMiddleware.AdminOnly)
+	authorizedScope.DELETE("/tax-rates/:id", taxHandler.DeleteTaxRate, accessMiddleware.AdminOnly)
+
+	// Регистрация маршрутов для расчетов с продавцами
+	authorizedScope.GET("/stores/:id/balance", ledgerHandler.GetStoreBalance)
+	authorizedScope.GET("/stores/:id/ledger", ledgerHandler.GetStoreTransactions)
+	authorizedScope.GET("/commission-rules", ledgerHandler.GetCommissionRules, accessMiddleware.AdminOnly)
+	authorizedScope.POST("/commission-rules", ledgerHandler.CreateCommissionRule, accessMiddleware.AdminOnly)
+	authorizedScope.DELETE("/commission-rules/:id", ledgerHandler.DeleteCommissionRule, accessMiddleware.AdminOnly)
+	authorizedScope.GET("/payouts/batches", ledgerHandler.GetPayoutBatches, accessMiddleware.AdminOnly)
+	authorizedScope.POST("/payouts/batches", ledgerHandler.CreatePayoutBatch, accessMiddleware.AdminOnly)
+	authorizedScope.GET("/ledger/reconciliation", ledgerHandler.Reconcile, accessMiddleware.AdminOnly)
+
+	// Регистрация маршрутов для счетов
+	authorizedScope.POST("/stores/:id/invoices", invoiceHandler.IssueInvoice, accessMiddleware.SellerAccess)
+	authorizedScope.GET("/stores/:id/invoices", invoiceHandler.GetStoreInvoices)
+	authorizedScope.GET("/invoices/:id", invoiceHandler.GetInvoice)
+	authorizedScope.GET("/invoices/:id/invoice.pdf", invoiceHandler.GetInvoiceDocument)
+	authorizedScope.POST("/invoices/:id/payment", paymentHandler.PayInvoice)
+	authorizedScope.GET("/invoices/:id/payment", paymentHandler.GetInvoicePayment)
+
+	// Регистрация маршрутов для возвратов
+	authorizedScope.POST("/invoices/:id/returns", returnHandler.RequestReturn)
+	authorizedScope.GET("/returns", returnHandler.GetUserReturns)
+	authorizedScope.GET("/stores/:id/returns", returnHandler.GetStoreReturns)
+	authorizedScope.GET("/returns/:id", returnHandler.GetReturn)
+	authorizedScope.POST("/returns/:id/photos", returnHandler.AddReturnPhoto, rateLimit(uploadLimit))
+	authorizedScope.GET("/returns/:id/photos/:name", returnHandler.GetReturnPhoto)
+	authorizedScope.POST("/returns/:id/approve", returnHandler.ApproveReturn, accessMiddleware.SellerAccess)
+	authorizedScope.POST("/returns/:id/reject", returnHandler.RejectReturn, accessMiddleware.SellerAccess)
+	authorizedScope.POST("/returns/:id/shipment", returnHandler.ShipReturn)
+	authorizedScope.POST("/returns/:id/receive", returnHandler.ReceiveReturn, accessMiddleware.SellerAccess)
+	authorizedScope.POST("/returns/:id/refund", returnHandler.RefundReturn, accessMiddleware.SellerAccess)
+
+	// Регистрация маршрутов для категорий
+	authorizedScope.GET("/categories", categoryHandler.GetCategoryTree)
+	authorizedScope.GET("/categories/:slug/products", categoryHandler.GetProductsByCategory)
+	authorizedScope.POST("/categories", categoryHandler.CreateCategory, accessMiddleware.AdminOnly)
+	authorizedScope.PUT("/categories/:id", categoryHandler.UpdateCategory, accessMiddleware.AdminOnly)
+	authorizedScope.DELETE("/categories/:id", categoryHandler.DeleteCategory, accessMiddleware.AdminOnly)
 	return nil
 }
@@ -5,4 +5,91 @@ import "time"
 const (
 	RefreshTokenLifetime = time.Hour * 24 * 365
 	AccessTokenLifetime  = 72 * time.Hour
+
+	EmailVerificationTokenLifetime = 48 * time.Hour
+	PasswordResetTokenLifetime     = time.Hour
+	MFAChallengeLifetime           = 5 * time.Minute // Сколько действует токен второго шага входа
+	AccountUnlockTokenLifetime     = time.Hour       // Сколько действует ссылка разблокировки учетной записи
+)
+
+const (
+	LoginFailureWindow      = time.Hour        // Сколько помнятся неудачные попытки входа после последней из них
+	LoginBackoffThreshold   = 3                // После скольких неудач подряд вход в учетную запись замедляется
+	IPBackoffThreshold      = 20               // То же для IP-адреса: за одним адресом может быть много пользователей
+	LoginBackoffBase        = time.Second      // Задержка после первой неудачи сверх порога, удваивается с каждой следующей
+	LoginBackoffMax         = 15 * time.Minute // Наибольшая задержка между попытками
+	AccountLockoutThreshold = 10               // После скольких неудач подряд учетная запись блокируется
+	AccountLockoutDuration  = time.Hour        // Срок блокировки учетной записи, если ее не сняли по ссылке из письма
+)
+
+const (
+	TOTPIssuer         = "Marketplace"    // Название сервиса в приложении-аутентификаторе
+	TOTPDigits         = 6                // Длина одноразового кода
+	TOTPPeriod         = 30 * time.Second // Период смены кода
+	TOTPSkewSteps      = 1                // На сколько периодов могут расходиться часы клиента и сервера
+	RecoveryCodesCount = 10               // Сколько резервных кодов выдается при включении 2FA
+)
+
+const (
+	BaseCurrency = "RUB" // Валюта, в которой продавцы указывают цены
+)
+
+const (
+	DefaultCommissionRate = 10.0 // Комиссия маркетплейса в процентах, если нет правила для магазина или категории
+)
+
+const (
+	MaxProductImageSize   = 10 << 20 // Максимальный размер загружаемого изображения, 10 МБ
+	MaxProductImagesCount = 10       // Максимальное количество изображений у продукта
+	MaxProductImagePixels = 40000000 // Максимальная площадь изображения в пикселях, 40 Мп
+	MaxReviewPhotosCount  = 5        // Максимальное количество фотографий в отзыве
+	MaxReturnPhotosCount  = 5        // Максимальное количество фотографий в заявке на возврат
+)
+
+// ThumbnailSizes размеры миниатюр изображений по наибольшей стороне в пикселях
+var ThumbnailSizes = map[string]int{
+	"small":  150,
+	"medium": 400,
+	"large":  800,
+}
+
+const (
+	DefaultPageSize = 20  // Размер страницы по умолчанию
+	MaxPageSize     = 100 // Максимальный размер страницы
+)
+
+const (
+	MaxWishlistsCount     = 20  // Максимальное количество списков желаний у пользователя
+	MaxWishlistItemsCount = 500 // Максимальное количество позиций в списке желаний
+)
+
+const (
+	MaxMessageAttachmentSize = 10 << 20 // Максимальный размер вложения в сообщение, 10 МБ
+)
+
+const (
+	EventStreamMaxLength = 1000             // Сколько последних событий топика хранится для возобновления доставки
+	EventHeartbeatPeriod = 30 * time.Second // Период проверки соединения с клиентом
+	EventPongTimeout     = 60 * time.Second // Сколько ждать ответа клиента, прежде чем закрыть соединение
+	EventKeepAlivePeriod = 15 * time.Second // Период комментариев SSE, не дающих прокси закрыть простаивающий поток
+	EventRetryInterval   = 3 * time.Second  // Через сколько клиент SSE переподключается после обрыва
+)
+
+const (
+	EmailSendAttempts = 4               // Сколько раз пытаться отправить письмо, прежде чем отказаться
+	EmailRetryDelay   = 5 * time.Second // Пауза перед повторной отправкой, удваивается с каждой попыткой
+)
+
+const (
+	MaxRequestBodySize = 11 << 20 // Максимальный размер тела запроса: файл до 10 МБ вместе с остальными полями формы
+)
+
+const (
+	RateLimiterRetryInterval = 5 * time.Second // Как часто проверять, вернулся ли Redis, пока запросы считаются в памяти
+)
+
+const (
+	IdempotencyKeyLifetime  = 24 * time.Hour // Сколько хранится ответ на запрос с ключом идемпотентности
+	IdempotencyLockTimeout  = time.Minute    // Сколько ключ остается захваченным, если сервер не завершил запрос
+	MaxIdempotencyKeyLength = 255            // Максимальная длина ключа идемпотентности
 )
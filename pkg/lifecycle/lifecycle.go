@@ -0,0 +1,92 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// Hook действия компонента при запуске и остановке приложения. Любое из них может отсутствовать.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle запускает компоненты в порядке регистрации хуков и останавливает в обратном,
+// так что компонент останавливается раньше всего, от чего он зависит
+type Lifecycle struct {
+	mu       sync.Mutex
+	hooks    []Hook
+	started  int // Сколько первых хуков успешно запущено
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+// New создает пустой Lifecycle
+func New() *Lifecycle {
+	return &Lifecycle{stopping: make(chan struct{})}
+}
+
+// Append регистрирует хук. Хуки, добавленные после Start, не запускаются.
+func (l *Lifecycle) Append(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start выполняет OnStart хуков по порядку и прекращает запуск при первой ошибке
+// или отмене ctx. Уже запущенные компоненты остаются запущенными: вызывающий
+// останавливает их через Stop со своим контекстом, так как ctx может быть уже отменен.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := l.hooks
+	l.mu.Unlock()
+
+	for i, hook := range hooks {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("failed to start %s: %w", hook.Name, err)
+		}
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				return fmt.Errorf("failed to start %s: %w", hook.Name, err)
+			}
+		}
+		l.mu.Lock()
+		l.started = i + 1
+		l.mu.Unlock()
+	}
+	return nil
+}
+
+// Stop выполняет OnStop запущенных хуков в обратном порядке. Ошибка одного хука
+// не прерывает остановку остальных; все ошибки возвращаются вместе.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stopping) })
+
+	l.mu.Lock()
+	hooks := l.hooks[:l.started]
+	l.started = 0
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if hook.OnStop == nil {
+			continue
+		}
+		logrus.Infof("Stopping %s", hook.Name)
+		if err := hook.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", hook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stopping возвращает канал, который закрывается в начале остановки. По нему
+// долгоживущие соединения завершаются до того, как HTTP-сервер начнет их ждать.
+func (l *Lifecycle) Stopping() <-chan struct{} {
+	return l.stopping
+}
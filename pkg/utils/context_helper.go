@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"time"
+)
+
+// UserIDFromContext извлекает ID пользователя, установленный JWTMiddleware
+func UserIDFromContext(c echo.Context) (uint64, error) {
+	// Claims JWT декодируются как float64
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return 0, errors.New("user id not found in context")
+	}
+	return uint64(userID), nil
+}
+
+// TokenIssuedAtFromContext возвращает время выпуска токена, установленное JWTMiddleware.
+// Для токенов без метки времени возвращается нулевое время.
+func TokenIssuedAtFromContext(c echo.Context) time.Time {
+	issuedAt, ok := c.Get("issued_at").(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(issuedAt), 0)
+}
@@ -0,0 +1,8 @@
+package utils
+
+import "strings"
+
+// NormalizeEmail приводит email к виду, в котором он хранится и сравнивается
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
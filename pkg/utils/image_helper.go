@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"bytes"
+	"golang.org/x/image/draw"
+	"image"
+	"image/jpeg"
+	"image/png"
+)
+
+// ResizeToFit уменьшает изображение так, чтобы наибольшая сторона не превышала maxSize.
+// Изображения меньше maxSize не увеличиваются.
+func ResizeToFit(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return src
+	}
+
+	// Сохраняем пропорции исходного изображения
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
+
+// EncodeImage кодирует изображение в PNG (для сохранения прозрачности) или JPEG
+func EncodeImage(img image.Image, asPNG bool) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if asPNG {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
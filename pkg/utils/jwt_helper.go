@@ -7,11 +7,16 @@ import (
 	"github.com/sirupsen/logrus"
 	"marketplace/internal/domain/entities"
 	"marketplace/internal/domain/enums"
-	"os"
 	"time"
 )
 
-var jwtSecret = []byte(os.Getenv("JWT_SECRET_KEY"))
+// jwtSecret ключ подписи токенов, задается из конфигурации при запуске
+var jwtSecret []byte
+
+// SetJWTSecret задает ключ подписи токенов. Должен вызываться до выпуска и проверки токенов.
+func SetJWTSecret(secret string) {
+	jwtSecret = []byte(secret)
+}
 
 // GenerateToken создает новые Access и Refresh токены
 func GenerateToken(userID uint64, tokenType enums.Token) (*entities.TokenDetails, error) {
@@ -24,6 +29,7 @@ func GenerateToken(userID uint64, tokenType enums.Token) (*entities.TokenDetails
 	accessClaims := jwt.MapClaims{
 		"user_id":     userID,
 		"exp":         tokenDetails.AtExpires,
+		"iat":         time.Now().Unix(), // Нужен, чтобы отзывать токены, выданные до сброса пароля
 		"access_uuid": tokenDetails.UUID,
 	}
 
@@ -37,6 +43,51 @@ func GenerateToken(userID uint64, tokenType enums.Token) (*entities.TokenDetails
 	return tokenDetails, nil
 }
 
+// GenerateActionToken создает подписанный одноразовый токен для ссылок из писем и второго шага входа.
+// Возвращает сам токен и его идентификатор, по которому отслеживается использование.
+func GenerateActionToken(userID uint64, purpose enums.ActionToken) (string, string, error) {
+	tokenID := uuid.New().String()
+	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":  userID,
+		"purpose":  string(purpose),
+		"token_id": tokenID,
+		"exp":      time.Now().Add(purpose.Duration()).Unix(),
+	})
+	tokenString, err := claims.SignedString(jwtSecret)
+	if err != nil {
+		return "", "", err
+	}
+	return tokenString, tokenID, nil
+}
+
+// ValidateActionToken проверяет подпись, срок действия и назначение одноразового токена.
+// Возвращает ID пользователя и идентификатор токена.
+func ValidateActionToken(tokenString string, purpose enums.ActionToken) (uint64, string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return jwtSecret, nil
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid token: %v", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["purpose"] != string(purpose) {
+		return 0, "", fmt.Errorf("invalid token")
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, "", fmt.Errorf("invalid token: user_id missing or invalid")
+	}
+	tokenID, ok := claims["token_id"].(string)
+	if !ok {
+		return 0, "", fmt.Errorf("invalid token: token_id missing or invalid")
+	}
+	return uint64(userID), tokenID, nil
+}
+
 // ValidateToken проверяет корректность и валидность токена (Access или Refresh)
 func ValidateToken(tokenString string) (*jwt.Token, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -0,0 +1,8 @@
+package utils
+
+import "math"
+
+// RoundPrice округляет цену или сумму до копеек
+func RoundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"marketplace/pkg/constants"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// totpEncoding кодировка секрета, которую понимают приложения-аутентификаторы
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret создает случайный секрет TOTP в кодировке base32
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, 20) // 160 бит, как рекомендует RFC 4226 для HMAC-SHA1
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TOTPProvisioningURI возвращает otpauth-ссылку для QR-кода приложения-аутентификатора
+func TOTPProvisioningURI(account, secret string) string {
+	label := url.PathEscape(constants.TOTPIssuer + ":" + account)
+	query := url.Values{
+		"secret":    {secret},
+		"issuer":    {constants.TOTPIssuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(constants.TOTPDigits)},
+		"period":    {fmt.Sprint(int(constants.TOTPPeriod.Seconds()))},
+	}
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// ValidateTOTP проверяет код по RFC 6238, допуская расхождение часов на TOTPSkewSteps периодов.
+// Возвращает шаг времени, которому соответствует код: по RFC 6238 (раздел 5.2) код
+// принимается только один раз, поэтому вызывающий отклоняет шаги не новее уже принятого.
+func ValidateTOTP(secret, code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != constants.TOTPDigits {
+		return 0, false
+	}
+
+	counter := now.Unix() / int64(constants.TOTPPeriod.Seconds())
+	matched, valid := int64(0), false
+	for step := -constants.TOTPSkewSteps; step <= constants.TOTPSkewSteps; step++ {
+		expected := totpCode(key, uint64(counter+int64(step)))
+		// Сравнение за постоянное время, без раннего выхода из цикла
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			matched, valid = counter+int64(step), true
+		}
+	}
+	return matched, valid
+}
+
+// totpCode вычисляет одноразовый код HOTP (RFC 4226) для значения счетчика
+func totpCode(key []byte, counter uint64) string {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < constants.TOTPDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", constants.TOTPDigits, value%modulo)
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"marketplace/pkg/constants"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret секрет тестовых векторов SHA1 из приложения B RFC 6238 ("12345678901234567890")
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// rfc6238Vectors время и 8-значный код из приложения B RFC 6238 для HMAC-SHA1
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "94287082"},
+	{1111111109, "07081804"},
+	{1111111111, "14050471"},
+	{1234567890, "89005924"},
+	{2000000000, "69279037"},
+	{20000000000, "65353130"},
+}
+
+func TestTOTPCodeRFC6238Vectors(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, vector := range rfc6238Vectors {
+		counter := uint64(vector.unix / int64(constants.TOTPPeriod.Seconds()))
+		// Код из TOTPDigits цифр — последние цифры 8-значного кода RFC
+		want := vector.code[len(vector.code)-constants.TOTPDigits:]
+		if got := totpCode(key, counter); got != want {
+			t.Errorf("totpCode at %d = %s, want %s", vector.unix, got, want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	period := int64(constants.TOTPPeriod.Seconds())
+	for _, vector := range rfc6238Vectors {
+		code := vector.code[len(vector.code)-constants.TOTPDigits:]
+		now := time.Unix(vector.unix, 0)
+
+		step, ok := ValidateTOTP(rfc6238Secret, code, now)
+		if !ok || step != vector.unix/period {
+			t.Errorf("ValidateTOTP at %d = (%d, %v), want (%d, true)", vector.unix, step, ok, vector.unix/period)
+		}
+
+		// Код остается действительным в пределах TOTPSkewSteps периодов и не дольше
+		skew := time.Duration(constants.TOTPSkewSteps) * constants.TOTPPeriod
+		if _, ok = ValidateTOTP(rfc6238Secret, code, now.Add(skew)); !ok {
+			t.Errorf("ValidateTOTP at %d rejected code within the allowed skew", vector.unix)
+		}
+		if _, ok = ValidateTOTP(rfc6238Secret, code, now.Add(skew+constants.TOTPPeriod)); ok {
+			t.Errorf("ValidateTOTP at %d accepted code outside the allowed skew", vector.unix)
+		}
+	}
+
+	if _, ok := ValidateTOTP(rfc6238Secret, "12345", time.Unix(59, 0)); ok {
+		t.Error("ValidateTOTP accepted a code of the wrong length")
+	}
+	if _, ok := ValidateTOTP("not base32!", "287082", time.Unix(59, 0)); ok {
+		t.Error("ValidateTOTP accepted an invalid secret")
+	}
+}
@@ -15,6 +15,10 @@ func AppValidate() *validator.Validate {
 		if err != nil {
 			logrus.Errorf("Failed to register validator: %v", err)
 		}
+		err = instance.RegisterValidation("slug", validateSlug)
+		if err != nil {
+			logrus.Errorf("Failed to register validator: %v", err)
+		}
 	}
 	return instance // Возврат инициализированного экземпляра
 }
@@ -40,3 +44,10 @@ func validatePassword(fl validator.FieldLevel) bool {
 	// Пароль должен содержать заглавную, строчную букву, цифру и спец. символ
 	return hasUppercase && hasLowercase && hasDigit && hasSpecialChar
 }
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Кастомный валидатор для slug: строчные латинские буквы и цифры, разделенные дефисами
+func validateSlug(fl validator.FieldLevel) bool {
+	return slugRegexp.MatchString(fl.Field().String())
+}